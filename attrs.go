@@ -0,0 +1,158 @@
+package traceable
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+const (
+	// structTagKey is the key of the struct tags naming the span tags that
+	// fields of arguments are recorded as, e.g. `trace:"search.query"`.
+	structTagKey = "trace"
+
+	// omitEmptyOption is the option of trace struct tags that skips the tag
+	// when the field is empty, e.g. `trace:"search.region,omitempty"`.
+	omitEmptyOption = "omitempty"
+
+	// maxAttrDepth is how deep fields are looked for struct tags in the
+	// structs passed as arguments, counting the fields of the arguments as
+	// depth 1.
+	maxAttrDepth = 3
+)
+
+// parseStructTags adds a tag to m for each exported field, of the structs
+// passed to m as arguments or nested in them, that has a trace struct tag.
+// The fields are read directly by the generated code; no reflection is used
+// at runtime.
+func (p *parser) parseStructTags(m *Method, params *types.Tuple) error {
+	for i := 0; i < params.Len(); i++ {
+		t := params.At(i).Type()
+		if isContextType(t) || m.isVariadic && i == params.Len()-1 {
+			continue
+		}
+
+		if err := p.addStructTags(m, argTag{arg: i, expr: params.At(i).Name()}, t, 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addStructTags adds the tags of the fields of t, if it is a struct or a
+// pointer to one, read from the value of tag.
+func (p *parser) addStructTags(m *Method, tag argTag, t types.Type, depth int) error {
+	if depth > maxAttrDepth {
+		return nil
+	}
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if !f.Exported() {
+			continue
+		}
+
+		field := tag
+		field.expr += "." + f.Name()
+		field.fields = append(append([]*types.Var(nil), tag.fields...), f)
+		if field.sensitive == "" && p.fieldDirectives[f.Pos()].has(sensitiveDirective) {
+			field.sensitive = f.Name()
+		}
+
+		opts := strings.Split(reflect.StructTag(st.Tag(i)).Get(structTagKey), ",")
+		if key := opts[0]; key != "" && key != "-" {
+			tagged := field
+			tagged.key = key
+			for _, opt := range opts[1:] {
+				if opt != omitEmptyOption {
+					return fmt.Errorf("can not tag %s with %s: unknown option %q in its trace struct tag", m.name, field.expr, opt)
+				}
+				if _, ok := f.Type().Underlying().(*types.Struct); ok {
+					return fmt.Errorf("can not tag %s with %s: %s does not apply to structs, which are never empty", m.name, field.expr, omitEmptyOption)
+				}
+				tagged.omitEmpty = true
+			}
+			m.tags = append(m.tags, tagged)
+		}
+
+		// The fields of embedded structs are promoted, so they are at the
+		// same depth as the struct. A struct embedding itself, directly or
+		// not, would then be followed forever, but its fields are shadowed
+		// by those already found at the same depth, so it is skipped.
+		next := depth + 1
+		if f.Embedded() {
+			if onPath(m, field) {
+				continue
+			}
+			next = depth
+		}
+		if err := p.addStructTags(m, field, f.Type(), next); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// onPath reports whether the struct type of the last field of tag, or the
+// type it points to, is also that of the argument or of a field before it.
+func onPath(m *Method, tag argTag) bool {
+	last := structOf(tag.fields[len(tag.fields)-1].Type())
+	if last == nil {
+		return false
+	}
+
+	path := []types.Type{m.args[tag.arg]}
+	for _, f := range tag.fields[:len(tag.fields)-1] {
+		path = append(path, f.Type())
+	}
+	for _, t := range path {
+		if s := structOf(t); s != nil && types.Identical(s, last) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// structOf returns the struct type of t, or of the type t points to, or nil
+// if there is none.
+func structOf(t types.Type) *types.Struct {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, _ := t.Underlying().(*types.Struct)
+	return st
+}
+
+// nonEmpty returns the condition that the value of expr, of type t, is not
+// empty, as defined by the omitempty option of encoding/json: false, 0, a
+// nil pointer, interface, channel or function, or an empty array, slice, map
+// or string.
+func nonEmpty(expr string, t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return expr
+		case u.Info()&types.IsString != 0:
+			return expr + ` != ""`
+		case u.Kind() == types.UnsafePointer:
+			return expr + " != nil"
+		}
+		return expr + " != 0"
+	case *types.Array, *types.Slice, *types.Map:
+		return "len(" + expr + ") != 0"
+	}
+
+	return expr + " != nil"
+}
@@ -0,0 +1,208 @@
+package traceable
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func Test_parser_parseStructTags(t *testing.T) {
+	pkg := parseSource(t, `package store
+
+import "context"
+
+type Request struct {
+	ID      string `+"`trace:\"request.id\"`"+`
+	Ignored string `+"`trace:\"-\"`"+`
+	hidden  string `+"`trace:\"hidden\"`"+`
+	//traceable:sensitive
+	SSN string `+"`trace:\"ssn\"`"+`
+	Meta
+	Level1 *Level1
+}
+
+type Meta struct {
+	Region string `+"`trace:\"region,omitempty\"`"+`
+}
+
+type Level1 struct {
+	A      int `+"`trace:\"l1\"`"+`
+	Level2 Level2
+}
+
+type Level2 struct {
+	B      int `+"`trace:\"l2\"`"+`
+	Level3 *Level3
+}
+
+type Level3 struct {
+	C int `+"`trace:\"l3\"`"+`
+}
+
+type Store interface {
+	Get(ctx context.Context, req *Request, ids ...Request) error
+}
+`)
+	i, err := pkg.lookupInterface("Store")
+	qt.Assert(t, err, qt.IsNil)
+
+	var got []string
+	for _, tag := range i.methods[0].tags {
+		s := tag.key + "=" + tag.expr
+		if tag.sensitive != "" {
+			s += " (sensitive " + tag.sensitive + ")"
+		}
+		if tag.omitEmpty {
+			s += " (omitempty)"
+		}
+		got = append(got, s)
+	}
+	qt.Check(t, got, qt.DeepEquals, []string{
+		"request.id=req.ID",
+		"ssn=req.SSN (sensitive SSN)",
+		"region=req.Meta.Region (omitempty)",
+		"l1=req.Level1.A",
+		"l2=req.Level1.Level2.B",
+	})
+}
+
+func Test_parser_parseStructTags_recursive(t *testing.T) {
+	pkg := parseSource(t, `package store
+
+import "context"
+
+type Node struct {
+	*Node
+	ID string `+"`trace:\"id\"`"+`
+}
+
+type Left struct {
+	*Right
+	L string `+"`trace:\"left\"`"+`
+}
+
+type Right struct {
+	*Left
+	R string `+"`trace:\"right\"`"+`
+}
+
+type List struct {
+	Next *List
+	ID   string `+"`trace:\"id\"`"+`
+}
+
+type Store interface {
+	Node(ctx context.Context, n *Node) error
+	Left(ctx context.Context, l Left) error
+	List(ctx context.Context, l *List) error
+}
+`)
+	i, err := pkg.lookupInterface("Store")
+	qt.Assert(t, err, qt.IsNil)
+
+	got := make(map[string][]string)
+	for _, m := range i.methods {
+		for _, tag := range m.tags {
+			got[m.name] = append(got[m.name], tag.key+"="+tag.expr)
+		}
+	}
+	qt.Check(t, got, qt.DeepEquals, map[string][]string{
+		"Node": {"id=n.ID"},
+		"Left": {"right=l.Right.R", "left=l.L"},
+		"List": {"id=l.Next.Next.ID", "id=l.Next.ID", "id=l.ID"},
+	})
+}
+
+func Test_parser_parseStructTags_options(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		wantErr string
+	}{
+		{
+			name:  "omitempty",
+			field: "Region string `trace:\"region,omitempty\"`",
+		},
+		{
+			name:    "unknown option",
+			field:   "Region string `trace:\"region,omitnil\"`",
+			wantErr: `can not tag Get with req.Region: unknown option "omitnil" in its trace struct tag`,
+		},
+		{
+			name:    "omitempty struct",
+			field:   "Meta struct{} `trace:\"meta,omitempty\"`",
+			wantErr: `can not tag Get with req.Meta: omitempty does not apply to structs, which are never empty`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := parseSource(t, `package store
+
+import "context"
+
+type Request struct {
+	`+tt.field+`
+}
+
+type Store interface {
+	Get(ctx context.Context, req *Request) error
+}
+`)
+			_, err := pkg.lookupInterface("Store")
+			if tt.wantErr != "" {
+				qt.Check(t, err, qt.ErrorMatches, tt.wantErr)
+				return
+			}
+			qt.Check(t, err, qt.IsNil)
+		})
+	}
+}
+
+func Test_nonEmpty(t *testing.T) {
+	pkg := parseSource(t, `package store
+
+import "unsafe"
+
+type Level int
+
+var (
+	b bool
+	s string
+	n Level
+	f float64
+	p *int
+	u unsafe.Pointer
+	i error
+	c chan int
+	fn func()
+	a [4]int
+	sl []int
+	m map[string]int
+)
+`)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "b", want: "b"},
+		{name: "s", want: `s != ""`},
+		{name: "n", want: "n != 0"},
+		{name: "f", want: "f != 0"},
+		{name: "p", want: "p != nil"},
+		{name: "u", want: "u != nil"},
+		{name: "i", want: "i != nil"},
+		{name: "c", want: "c != nil"},
+		{name: "fn", want: "fn != nil"},
+		{name: "a", want: "len(a) != 0"},
+		{name: "sl", want: "len(sl) != 0"},
+		{name: "m", want: "len(m) != 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := pkg.types.Scope().Lookup(tt.name)
+			qt.Assert(t, v, qt.Not(qt.IsNil))
+			qt.Check(t, nonEmpty(tt.name, v.Type()), qt.Equals, tt.want)
+		})
+	}
+}
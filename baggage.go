@@ -0,0 +1,59 @@
+package traceable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// baggageDirective is the name of the directive listing the arguments of a
+// method that are set as baggage items of its span, and so propagated to
+// the spans that follow from it.
+const baggageDirective = "baggage"
+
+// DefaultBaggageMaxLen is the length, in bytes, that baggage values are
+// truncated to unless the Generator's BaggageMaxLen is set.
+const DefaultBaggageMaxLen = 128
+
+// baggageMaxLen returns the length that baggage values are truncated to.
+func (g *Generator) baggageMaxLen() int {
+	if g.BaggageMaxLen > 0 {
+		return g.BaggageMaxLen
+	}
+
+	return DefaultBaggageMaxLen
+}
+
+// validateBaggage checks that the baggage items promoted to tags may be
+// recorded.
+func (g *Generator) validateBaggage() error {
+	for _, key := range g.BaggageTags {
+		if denied := g.deniedName(key); denied != "" {
+			return fmt.Errorf("refusing to tag the baggage item %s: the name %s matches %q in the deny-list", key, key, denied)
+		}
+	}
+
+	return nil
+}
+
+// printBaggage prints the calls setting the baggage items of the span of m
+// from its arguments, and then promoting the baggage items listed in
+// BaggageTags to tags.
+func (g *Generator) printBaggage(m Method) {
+	rt := g.importName(runtimePackagePath)
+	maxLen := g.baggageMaxLen()
+	for _, tag := range m.baggage {
+		g.printGuarded(m, tag, func(value string) {
+			g.Printf("%s.SetBaggage(span, %d, %q, %s)\n", rt, maxLen, tag.key, value)
+		})
+	}
+
+	if len(g.BaggageTags) == 0 {
+		return
+	}
+	keys := make([]string, len(g.BaggageTags))
+	for i, key := range g.BaggageTags {
+		keys[i] = strconv.Quote(key)
+	}
+	g.Printf("%s.TagBaggage(span, %d, %s)\n", rt, maxLen, strings.Join(keys, ", "))
+}
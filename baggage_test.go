@@ -0,0 +1,81 @@
+package traceable
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestGenerator_validate_baggage(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		baggageTags []string
+		wantErr     string
+	}{
+		{
+			name: "allowed",
+			method: `//traceable:baggage tenant.id=tenant, experiment.id=a.Experiment
+	Assign(ctx context.Context, tenant string, a *Assignment) error`,
+			baggageTags: []string{"tenant.id", "experiment.id"},
+		},
+		{
+			name: "sensitive parameter",
+			method: `//traceable:baggage pin
+	//traceable:sensitive pin
+	Login(ctx context.Context, pin string) error`,
+			wantErr: `refusing to set baggage of Store.Login from pin: pin is annotated with //traceable:sensitive`,
+		},
+		{
+			name: "denied field",
+			method: `//traceable:baggage a.Token
+	Assign(ctx context.Context, a *Assignment) error`,
+			wantErr: `refusing to set baggage of Store.Assign from a.Token: the name Token matches "token" in the deny-list`,
+		},
+		{
+			name: "unknown parameter",
+			method: `//traceable:baggage tenant
+	Assign(ctx context.Context, a *Assignment) error`,
+			wantErr: `//traceable:baggage tenant on Assign: tenant is not a parameter of Assign`,
+		},
+		{
+			name:        "denied baggage tag",
+			method:      `Assign(ctx context.Context, a *Assignment) error`,
+			baggageTags: []string{"tenant.id", "session.token"},
+			wantErr:     `refusing to tag the baggage item session.token: the name session.token matches "token" in the deny-list`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := parseSource(t, `package store
+
+import "context"
+
+type Assignment struct {
+	Experiment string
+	Token      string
+}
+
+type Store interface {
+	`+tt.method+`
+}
+`)
+			i, err := pkg.lookupInterface("Store")
+			if err == nil {
+				g := &Generator{RootPackage: "example.com/store", OutputPackagePath: "example.com/store", Interface: *i, BaggageTags: tt.baggageTags}
+				err = g.validate("Store")
+			}
+			if tt.wantErr == "" {
+				qt.Check(t, err, qt.IsNil)
+				return
+			}
+			qt.Check(t, err, qt.ErrorMatches, tt.wantErr)
+		})
+	}
+}
+
+func TestGenerator_baggageMaxLen(t *testing.T) {
+	qt.Check(t, (&Generator{}).baggageMaxLen(), qt.Equals, DefaultBaggageMaxLen)
+	qt.Check(t, (&Generator{BaggageMaxLen: 64}).baggageMaxLen(), qt.Equals, 64)
+}
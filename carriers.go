@@ -0,0 +1,212 @@
+package traceable
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+// carrierDirective is the name of the directive marking types that span
+// contexts can be propagated in, which must implement
+// opentracing.TextMapWriter to inject them and opentracing.TextMapReader to
+// extract them.
+const carrierDirective = "carrier"
+
+const (
+	httpPackagePath     = "net/http"
+	metadataPackagePath = "google.golang.org/grpc/metadata"
+)
+
+// carrierKind is the kind of value a span context is propagated in.
+type carrierKind int
+
+const (
+	httpHeaderCarrier carrierKind = iota + 1
+	metadataCarrier
+	textMapCarrier
+	annotatedCarrier
+)
+
+// carrier is an argument of a method that the span context of its span is
+// propagated in.
+type carrier struct {
+	// arg is the index of the parameter.
+	arg  int
+	kind carrierKind
+}
+
+// parseCarriers sets the carriers of m, the arguments whose types are known
+// to carry span contexts or are annotated with //traceable:carrier.
+func (p *parser) parseCarriers(m *Method) {
+	for i, t := range m.args {
+		if m.isVariadic && i == len(m.args)-1 {
+			continue
+		}
+		if kind := p.carrierKind(t); kind != 0 {
+			m.carriers = append(m.carriers, carrier{arg: i, kind: kind})
+		}
+	}
+}
+
+// carrierKind returns the kind of carrier values of type t are, or 0 if they
+// are not one.
+func (p *parser) carrierKind(t types.Type) carrierKind {
+	switch {
+	case isNamedType(t, httpPackagePath, "Header"):
+		return httpHeaderCarrier
+	case isNamedType(t, metadataPackagePath, "MD"):
+		return metadataCarrier
+	}
+
+	named := t
+	if ptr, ok := t.(*types.Pointer); ok {
+		named = ptr.Elem()
+	}
+	if n, ok := named.(*types.Named); ok && p.typeDirectives[n.Obj().Pos()].has(carrierDirective) {
+		return annotatedCarrier
+	}
+
+	if types.Identical(t.Underlying(), types.NewMap(types.Typ[types.String], types.Typ[types.String])) {
+		return textMapCarrier
+	}
+
+	return 0
+}
+
+// isNamedType reports whether t is the type name declared in the package at
+// path.
+func isNamedType(t types.Type, path, name string) bool {
+	n, ok := t.(*types.Named)
+	return ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == path && n.Obj().Name() == name
+}
+
+var (
+	stringPair = types.NewTuple(
+		types.NewVar(token.NoPos, nil, "key", types.Typ[types.String]),
+		types.NewVar(token.NoPos, nil, "val", types.Typ[types.String]),
+	)
+	errorResult = types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type()))
+
+	// textMapWriter is opentracing.TextMapWriter.
+	textMapWriter = types.NewInterfaceType([]*types.Func{
+		types.NewFunc(token.NoPos, nil, "Set", types.NewSignature(nil, stringPair, nil, false)),
+	}, nil).Complete()
+	// textMapReader is opentracing.TextMapReader.
+	textMapReader = types.NewInterfaceType([]*types.Func{
+		types.NewFunc(token.NoPos, nil, "ForeachKey", types.NewSignature(nil, types.NewTuple(
+			types.NewVar(token.NoPos, nil, "handler", types.NewSignature(nil, stringPair, errorResult, false)),
+		), errorResult, false)),
+	}, nil).Complete()
+)
+
+// extractsCarriers reports whether the wrapper of the Interface extracts
+// the parents of its spans from carriers, as the spans of servers and
+// consumers continue traces started elsewhere, rather than injecting its
+// spans into them.
+func (g *Generator) extractsCarriers() bool {
+	for _, tag := range g.Interface.tags {
+		if tag.key == "span.kind" {
+			return tag.value == "server" || tag.value == "consumer"
+		}
+	}
+
+	return false
+}
+
+// validateCarriers checks that the carriers annotated with
+// //traceable:carrier that the traced methods of the Interface propagate
+// their spans in implement the interface needed to do so.
+func (g *Generator) validateCarriers(typeName string) error {
+	iface, name := textMapWriter, "opentracing.TextMapWriter"
+	if g.extractsCarriers() {
+		iface, name = textMapReader, "opentracing.TextMapReader"
+	}
+
+	for _, m := range g.Interface.methods {
+		if !g.traced(getStructName(typeName), m) {
+			continue
+		}
+
+		for _, c := range m.carriers {
+			t := m.args[c.arg]
+			if c.kind == annotatedCarrier && !types.Implements(t, iface) {
+				return fmt.Errorf("can not propagate the span of %s.%s in %s: %s is annotated with %s%s but does not implement %s", typeName, m.name, m.argNames[c.arg], types.TypeString(t, nil), directivePrefix, carrierDirective, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// carrierArgs returns the format and carrier arguments of the
+// runtime.Inject or runtime.Extract call propagating a span in c.
+func (g *Generator) carrierArgs(c carrier) (format, value string) {
+	ot := g.importName(openTracingPackagePath)
+	arg := "a" + strconv.Itoa(c.arg)
+	switch c.kind {
+	case httpHeaderCarrier:
+		return ot + ".HTTPHeaders", ot + ".HTTPHeadersCarrier(" + arg + ")"
+	case metadataCarrier:
+		return ot + ".HTTPHeaders", g.importName(runtimePackagePath) + ".MetadataCarrier(" + arg + ")"
+	case textMapCarrier:
+		return ot + ".TextMap", ot + ".TextMapCarrier(" + arg + ")"
+	default:
+		return ot + ".TextMap", arg
+	}
+}
+
+// extractOption returns the argument of the call to runtime.StartSpan in m
+// that makes its span a child of the span context in its first carrier, if
+// its parents are extracted from carriers.
+func (g *Generator) extractOption(m Method) string {
+	if !g.extractsCarriers() || len(m.carriers) == 0 {
+		return ""
+	}
+
+	format, value := g.carrierArgs(m.carriers[0])
+	return fmt.Sprintf(", %s.Extract(%s, t.o, %s, %s)", g.importName(runtimePackagePath), m.contextArg(), format, value)
+}
+
+// printInject prints the calls injecting the span of m into its carriers,
+// unless its parents are extracted from them.
+func (g *Generator) printInject(m Method) {
+	if g.extractsCarriers() {
+		return
+	}
+
+	rt := g.importName(runtimePackagePath)
+	for _, c := range m.carriers {
+		format, value := g.carrierArgs(c)
+		if !nillable(m.args[c.arg]) {
+			g.Printf("%s.Inject(span, t.o, %s, %s)\n", rt, format, value)
+			continue
+		}
+
+		g.Printf("if a%d != nil {\n", c.arg)
+		g.Printf("%s.Inject(span, t.o, %s, %s)\n", rt, format, value)
+		g.Printf("}\n")
+	}
+}
+
+// propagates reports whether any traced method of the wrapper of typeName
+// propagates its span in a carrier.
+func (g *Generator) propagates(typeName string) bool {
+	for _, m := range g.Interface.methods {
+		if g.traced(getStructName(typeName), m) && len(m.carriers) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nillable reports whether values of type t can be nil.
+func nillable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Map, *types.Slice, *types.Interface, *types.Chan, *types.Signature:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,130 @@
+package traceable
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func Test_parseCarriers(t *testing.T) {
+	pkg := parseSource(t, `package store
+
+import (
+	"context"
+	"net/http"
+)
+
+type Labels map[string]string
+
+//traceable:carrier
+type Envelope struct{}
+
+func (*Envelope) Set(key, val string) {}
+
+type Message struct{}
+
+type Store interface {
+	Do(ctx context.Context, h http.Header, m map[string]string, l Labels, e *Envelope, v Envelope, msg *Message, s string, more ...map[string]string) error
+}
+`)
+	i, err := pkg.lookupInterface("Store")
+	qt.Assert(t, err, qt.IsNil)
+	qt.Check(t, fmt.Sprint(i.methods[0].carriers), qt.Equals, fmt.Sprint([]carrier{
+		{arg: 1, kind: httpHeaderCarrier},
+		{arg: 2, kind: textMapCarrier},
+		{arg: 3, kind: textMapCarrier},
+		{arg: 4, kind: annotatedCarrier},
+		{arg: 5, kind: annotatedCarrier},
+	}))
+}
+
+func Test_carrierKind_metadata(t *testing.T) {
+	pkg := types.NewPackage(metadataPackagePath, "metadata")
+	md := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "MD", nil), types.NewMap(types.Typ[types.String], types.NewSlice(types.Typ[types.String])), nil)
+
+	qt.Check(t, (&parser{}).carrierKind(md), qt.Equals, metadataCarrier)
+	qt.Check(t, (&parser{}).carrierKind(md.Underlying()), qt.Equals, carrierKind(0))
+}
+
+func TestGenerator_validateCarriers(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		method  string
+		wantErr string
+	}{
+		{
+			name:   "writer",
+			kind:   "client",
+			method: "Send(ctx context.Context, w *Writer) error",
+		},
+		{
+			name:    "not a writer",
+			kind:    "client",
+			method:  "Send(ctx context.Context, r *Reader) error",
+			wantErr: `can not propagate the span of Store.Send in r: \*example.com/store.Reader is annotated with //traceable:carrier but does not implement opentracing.TextMapWriter`,
+		},
+		{
+			name:    "value receiver",
+			method:  "Send(ctx context.Context, w Writer) error",
+			wantErr: `can not propagate the span of Store.Send in w: example.com/store.Writer is annotated with //traceable:carrier but does not implement opentracing.TextMapWriter`,
+		},
+		{
+			name:   "reader",
+			kind:   "server",
+			method: "Receive(ctx context.Context, r *Reader) error",
+		},
+		{
+			name:    "not a reader",
+			kind:    "consumer",
+			method:  "Receive(ctx context.Context, w *Writer) error",
+			wantErr: `can not propagate the span of Store.Receive in w: \*example.com/store.Writer is annotated with //traceable:carrier but does not implement opentracing.TextMapReader`,
+		},
+		{
+			name: "not traced",
+			method: `//traceable:skip
+	Send(ctx context.Context, r *Reader) error`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kind := ""
+			if tt.kind != "" {
+				kind = "//traceable:kind " + tt.kind
+			}
+			pkg := parseSource(t, `package store
+
+import "context"
+
+//traceable:carrier
+type Writer struct{}
+
+func (*Writer) Set(key, val string) {}
+
+//traceable:carrier
+type Reader struct{}
+
+func (*Reader) ForeachKey(handler func(key, val string) error) error { return nil }
+
+`+kind+`
+type Store interface {
+	`+tt.method+`
+}
+`)
+			i, err := pkg.lookupInterface("Store")
+			qt.Assert(t, err, qt.IsNil)
+
+			g := &Generator{RootPackage: "example.com/store", OutputPackagePath: "example.com/store", Interface: *i}
+			err = g.validate("Store")
+			if tt.wantErr == "" {
+				qt.Check(t, err, qt.IsNil)
+				return
+			}
+			qt.Check(t, err, qt.ErrorMatches, tt.wantErr)
+		})
+	}
+}
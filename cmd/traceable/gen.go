@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ConorNevin/traceable"
+)
+
+// config is the configuration read by traceable gen.
+type config struct {
+	// Targets are the files to generate. Paths are relative to the directory
+	// containing the configuration file.
+	Targets []targetConfig `yaml:"targets"`
+	// Deny lists the values that must never be tagged by any target, in
+	// addition to the defaults.
+	Deny denyConfig `yaml:"deny"`
+	// ContextTags are recorded from the context of every traced method of
+	// every target, written as key=importpath.Func.
+	ContextTags []string `yaml:"context-tags"`
+	// Baggage configures the baggage items recorded by every target.
+	Baggage baggageConfig `yaml:"baggage"`
+}
+
+// baggageConfig lists the baggage items recorded as tags, and the length
+// baggage values are truncated to.
+type baggageConfig struct {
+	Tags   []string `yaml:"tags"`
+	MaxLen int      `yaml:"max-len"`
+}
+
+// denyConfig is the project-wide deny-list.
+type denyConfig struct {
+	Names []string `yaml:"names"`
+	Types []string `yaml:"types"`
+}
+
+// targetConfig configures the generation of a file, as the flags of a
+// single traceable invocation do.
+type targetConfig struct {
+	// Package is the directory of the package declaring the types.
+	Package string   `yaml:"package"`
+	Types   []string `yaml:"types"`
+	// Output is the name of the generated file, relative to Package.
+	Output    string `yaml:"output"`
+	Include   string `yaml:"include"`
+	Exclude   string `yaml:"exclude"`
+	EmitTests bool   `yaml:"emit-tests"`
+	Fake      bool   `yaml:"fake"`
+	NoTrace   bool   `yaml:"notrace"`
+	// RecordSizes records the lengths of arguments and results on spans.
+	RecordSizes bool `yaml:"record-sizes"`
+}
+
+// job is a target to generate along with the Generator that generates it.
+type job struct {
+	g   *traceable.Generator
+	dir string
+	t   target
+}
+
+// genFlags are the flags of traceable gen.
+type genFlags struct {
+	config  *string
+	workers *int
+	force   *bool
+	load    loadFlags
+}
+
+// addGenFlags defines the flags of traceable gen in fs.
+func addGenFlags(fs *flag.FlagSet) genFlags {
+	return genFlags{
+		config:  fs.String("config", "traceable.yaml", "configuration file listing the files to generate"),
+		workers: fs.Int("j", runtime.GOMAXPROCS(0), "number of files to generate concurrently"),
+		force:   fs.Bool("force", false, "generate every file even if the signatures recorded in it show it is up to date"),
+		load:    addLoadFlags(fs),
+	}
+}
+
+// runGen generates every target in a configuration file in a single process:
+// the packages of the targets that are out of date are loaded together, and
+// the targets are generated concurrently.
+func runGen(args []string) error {
+	fs := flag.NewFlagSet("traceable gen", flag.ExitOnError)
+	f := addGenFlags(fs)
+	_ = fs.Parse(args)
+
+	if *f.workers < 1 {
+		return errors.New("-j must be at least 1")
+	}
+
+	root, all, err := f.jobs()
+	if err != nil {
+		return err
+	}
+	var jobs []job
+	for _, j := range all {
+		if !*f.force && upToDate(j.g, j.t, j.dir) {
+			log.Printf("%s is up to date", j.t.output)
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+	if err := loadJobs(root, jobs, f.load); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(jobs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < *f.workers && w < len(jobs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = jobs[i].run()
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	// A target that fails does not stop the others from being generated, so
+	// that every failure is reported at once.
+	var failed int
+	for i, err := range errs {
+		if err != nil {
+			log.Printf("%s: %s", jobs[i].t.output, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d targets failed", failed, len(jobs))
+	}
+
+	return nil
+}
+
+// jobs returns the jobs generating the targets of the configuration file,
+// along with the directory containing it, which their paths are relative to.
+func (f genFlags) jobs() (string, []job, error) {
+	root, err := filepath.Abs(filepath.Dir(*f.config))
+	if err != nil {
+		return "", nil, err
+	}
+	cfg, err := readConfig(*f.config)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var jobs []job
+	for i, tc := range cfg.Targets {
+		j, err := newJob(root, tc, cfg, f.load)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: target %d: %w", *f.config, i, err)
+		}
+		jobs = append(jobs, j)
+	}
+
+	return root, jobs, nil
+}
+
+// loadJobs loads the packages of the jobs, whose paths are relative to root,
+// with a single call to packages.Load, and makes their Generators use them.
+func loadJobs(root string, jobs []job, load loadFlags) error {
+	var patterns []string
+	for _, j := range jobs {
+		patterns = append(patterns, packagePattern(root, j.dir))
+		patterns = append(patterns, j.t.packages()...)
+		patterns = append(patterns, j.g.ContextTagPackages()...)
+	}
+
+	pkgs, err := traceable.LoadPackages(root, patterns, load.options())
+	if err != nil {
+		return err
+	}
+	for _, j := range jobs {
+		if _, ok := pkgs.PackagePath(j.dir); !ok {
+			return fmt.Errorf("no package found in %s", j.dir)
+		}
+		j.g.UsePackages(pkgs)
+	}
+
+	return nil
+}
+
+// run generates the files of j, writing those whose contents changed.
+func (j job) run() error {
+	files, err := generate(j.g, j.t)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if existing, err := ioutil.ReadFile(f.name); err == nil && bytes.Equal(existing, f.src) {
+			continue
+		}
+		if err := writeFile(f.name, f.src); err != nil {
+			return err
+		}
+		log.Printf("wrote %s", f.name)
+	}
+
+	return nil
+}
+
+// readConfig reads the configuration file name.
+func readConfig(name string) (*config, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cfg config
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return &cfg, nil
+}
+
+// newJob returns the job generating the target configured by tc, whose
+// paths are relative to root, with the project-wide settings of cfg, from
+// packages loaded as set by load.
+func newJob(root string, tc targetConfig, cfg *config, load loadFlags) (job, error) {
+	switch {
+	case len(tc.Types) == 0:
+		return job{}, errors.New("types must be set")
+	case tc.Output == "":
+		return job{}, errors.New("output must be set")
+	case tc.EmitTests && tc.Fake:
+		return job{}, errors.New("emit-tests can not be used with fake")
+	case tc.NoTrace && tc.Fake:
+		return job{}, errors.New("notrace can not be used with fake")
+	case cfg.Baggage.MaxLen < 0:
+		return job{}, errors.New("baggage max-len must not be negative")
+	}
+
+	dir := filepath.Join(root, tc.Package)
+	t := target{
+		types:     tc.Types,
+		output:    filepath.Join(dir, tc.Output),
+		emitTests: tc.EmitTests,
+		fake:      tc.Fake,
+		notrace:   tc.NoTrace,
+	}
+
+	g := &traceable.Generator{
+		Args:          append(append(tc.args(), cfg.args()...), load.args()...),
+		Deny:          traceable.DenyList{Names: cfg.Deny.Names, Types: cfg.Deny.Types},
+		RecordSizes:   tc.RecordSizes,
+		BaggageTags:   cfg.Baggage.Tags,
+		BaggageMaxLen: cfg.Baggage.MaxLen,
+		LoadOptions:   load.options(),
+	}
+	var err error
+	if g.ContextTags, err = parseContextTags(cfg.ContextTags); err != nil {
+		return job{}, err
+	}
+	if g.Include, err = compileFlag("include", tc.Include); err != nil {
+		return job{}, err
+	}
+	if g.Exclude, err = compileFlag("exclude", tc.Exclude); err != nil {
+		return job{}, err
+	}
+	if g.RootPackage, err = parsePackageImport(dir); err != nil {
+		return job{}, err
+	}
+	if g.OutputPackagePath, err = parsePackageImport(filepath.Dir(t.output)); err != nil {
+		return job{}, err
+	}
+
+	return job{g: g, dir: dir, t: t}, nil
+}
+
+// args returns the flags of the traceable invocation equivalent to tc,
+// which are recorded in the header of the generated files.
+func (tc targetConfig) args() []string {
+	args := []string{"-types", strings.Join(tc.Types, ","), "-output", tc.Output}
+	if tc.Include != "" {
+		args = append(args, "-include", tc.Include)
+	}
+	if tc.Exclude != "" {
+		args = append(args, "-exclude", tc.Exclude)
+	}
+	if tc.EmitTests {
+		args = append(args, "-emit-tests")
+	}
+	if tc.Fake {
+		args = append(args, "-fake")
+	}
+	if tc.NoTrace {
+		args = append(args, "-notrace")
+	}
+	if tc.RecordSizes {
+		args = append(args, "-record-sizes")
+	}
+
+	return args
+}
+
+// args returns the flags of the traceable invocation equivalent to the
+// project-wide settings of cfg.
+func (cfg *config) args() []string {
+	args := cfg.Deny.args()
+	if len(cfg.ContextTags) > 0 {
+		args = append(args, "-context-tags", strings.Join(cfg.ContextTags, ","))
+	}
+	if len(cfg.Baggage.Tags) > 0 {
+		args = append(args, "-baggage-tags", strings.Join(cfg.Baggage.Tags, ","))
+	}
+	if cfg.Baggage.MaxLen > 0 {
+		args = append(args, "-baggage-max-len", strconv.Itoa(cfg.Baggage.MaxLen))
+	}
+
+	return args
+}
+
+// args returns the flags of the traceable invocation equivalent to d.
+func (d denyConfig) args() []string {
+	var args []string
+	if len(d.Names) > 0 {
+		args = append(args, "-deny-names", strings.Join(d.Names, ","))
+	}
+	if len(d.Types) > 0 {
+		args = append(args, "-deny-types", strings.Join(d.Types, ","))
+	}
+
+	return args
+}
+
+// packagePattern returns the pattern matching the package in dir, relative
+// to root.
+func packagePattern(root, dir string) string {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return dir
+	}
+
+	return "./" + filepath.ToSlash(rel)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/tools/go/packages"
+)
+
+var update = flag.Bool("update", false, "update the golden files under internal/tests")
+
+const (
+	moduleDir   = "../.."
+	fixturesDir = moduleDir + "/internal/tests"
+)
+
+// directive is a //go:generate directive that runs traceable, found in a
+// fixture under internal/tests.
+type directive struct {
+	dir  string
+	args []string
+}
+
+// TestGolden runs traceable over every fixture under internal/tests, with the
+// arguments of its go:generate directives, and compares the output with the
+// committed files. Run with -update to rewrite them.
+//
+// The generated files are also type-checked, along with the packages they
+// belong to, so that output that does not compile fails the test even if it
+// matches the golden files.
+func TestGolden(t *testing.T) {
+	c := qt.New(t)
+
+	directives, err := findDirectives(fixturesDir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(directives, qt.Not(qt.HasLen), 0)
+
+	overlay := make(map[string][]byte)
+	var tags []string
+	for _, d := range directives {
+		d := d
+		c.Run(strings.Join(append([]string{d.dir}, d.args...), " "), func(c *qt.C) {
+			files, load := d.generate(c)
+			tags = append(tags, load.options().Tags...)
+			for _, f := range files {
+				abs, err := filepath.Abs(f.name)
+				c.Assert(err, qt.IsNil)
+				overlay[abs] = f.src
+
+				if *update {
+					c.Assert(os.MkdirAll(filepath.Dir(f.name), os.ModePerm), qt.IsNil)
+					c.Assert(ioutil.WriteFile(f.name, f.src, 0644), qt.IsNil)
+					continue
+				}
+
+				want, err := ioutil.ReadFile(f.name)
+				c.Assert(err, qt.IsNil, qt.Commentf("run go test -run TestGolden -update to create it"))
+				c.Check(string(f.src), qt.Equals, string(want), qt.Commentf("%s is out of date; run go test -run TestGolden -update", f.name))
+			}
+		})
+	}
+
+	// Type-check the fixtures declared under build constraints too, both
+	// with and without the pass-through wrappers.
+	for _, tags := range [][]string{tags, append(tags, "notrace")} {
+		tags := tags
+		t.Run("type-check -tags="+strings.Join(tags, ","), func(t *testing.T) {
+			cfg := &packages.Config{
+				Mode:       packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+				Dir:        moduleDir,
+				Overlay:    overlay,
+				Tests:      true,
+				BuildFlags: []string{"-tags=" + strings.Join(tags, ",")},
+			}
+			pkgs, err := packages.Load(cfg, "./internal/tests/...")
+			qt.Assert(t, err, qt.IsNil)
+
+			packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+				for _, err := range pkg.Errors {
+					t.Errorf("%s: %s", pkg.ID, err)
+				}
+			})
+		})
+	}
+}
+
+// TestNoTraceDeps checks that the pass-through wrappers compile tracing out
+// entirely: built with the notrace tag, the fixture does not depend on
+// opentracing.
+func TestNoTraceDeps(t *testing.T) {
+	cfg := &packages.Config{
+		Mode:       packages.NeedName | packages.NeedImports | packages.NeedDeps,
+		Dir:        moduleDir,
+		BuildFlags: []string{"-tags=notrace"},
+	}
+	pkgs, err := packages.Load(cfg, "./internal/tests/notrace")
+	qt.Assert(t, err, qt.IsNil)
+
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if strings.HasPrefix(pkg.PkgPath, "github.com/opentracing/") {
+			t.Errorf("the notrace fixture depends on %s", pkg.PkgPath)
+		}
+	})
+}
+
+// generate runs traceable, in the directory of d, as go generate would for
+// d, returning the files it outputs, named relative to the current
+// directory, and the flags controlling how it loaded the packages.
+func (d directive) generate(c *qt.C) ([]generatedFile, loadFlags) {
+	wd, err := os.Getwd()
+	c.Assert(err, qt.IsNil)
+	c.Assert(os.Chdir(d.dir), qt.IsNil)
+	defer func() {
+		c.Assert(os.Chdir(wd), qt.IsNil)
+	}()
+
+	var (
+		files []generatedFile
+		load  loadFlags
+	)
+	if len(d.args) > 0 && d.args[0] == "gen" {
+		files, load = d.generateConfig(c)
+	} else {
+		fs := flag.NewFlagSet("traceable", flag.ContinueOnError)
+		f := addFlags(fs)
+		c.Assert(fs.Parse(d.args), qt.IsNil)
+
+		g, t, err := f.generator()
+		c.Assert(err, qt.IsNil)
+		g.Args = d.args
+		c.Assert(g.ParsePackage(loadPatterns(g, t, fs.Args())), qt.IsNil)
+		files, err = generate(g, t)
+		c.Assert(err, qt.IsNil)
+		load = f.load
+	}
+
+	for i, f := range files {
+		if !filepath.IsAbs(f.name) {
+			files[i].name = filepath.Join(d.dir, f.name)
+		}
+	}
+
+	return files, load
+}
+
+// generateConfig runs traceable gen for d, in the current directory,
+// generating every target of its configuration file.
+func (d directive) generateConfig(c *qt.C) ([]generatedFile, loadFlags) {
+	fs := flag.NewFlagSet("traceable gen", flag.ContinueOnError)
+	f := addGenFlags(fs)
+	c.Assert(fs.Parse(d.args[1:]), qt.IsNil)
+
+	root, jobs, err := f.jobs()
+	c.Assert(err, qt.IsNil)
+	c.Assert(loadJobs(root, jobs, f.load), qt.IsNil)
+
+	var files []generatedFile
+	for _, j := range jobs {
+		generated, err := generate(j.g, j.t)
+		c.Assert(err, qt.IsNil)
+		files = append(files, generated...)
+	}
+
+	return files, f.load
+}
+
+// findDirectives returns the go:generate directives that run traceable in
+// the Go files under root.
+func findDirectives(root string) ([]directive, error) {
+	var directives []directive
+	err := filepath.Walk(root, func(name string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(name, ".go") {
+			return err
+		}
+
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		s := bufio.NewScanner(f)
+		for s.Scan() {
+			fields := strings.Fields(s.Text())
+			if len(fields) < 2 || fields[0] != "//go:generate" || path.Base(fields[1]) != "traceable" {
+				continue
+			}
+			directives = append(directives, directive{dir: filepath.Dir(name), args: fields[2:]})
+		}
+
+		return s.Err()
+	})
+
+	return directives, err
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
@@ -8,73 +9,335 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"golang.org/x/mod/modfile"
 
 	"github.com/ConorNevin/traceable"
 )
 
-var (
-	typeNames = flag.String("types", "", "comma-separated list of type names; must be set")
-	output    = flag.String("output", "", "output file name; default srcdir/traced_<type>.go")
-)
+// flags are the flags of a traceable invocation.
+type flags struct {
+	types, output, include, exclude *string
+	emitTests, fake, notrace        *bool
+	watch, force                    *bool
+	interval                        *time.Duration
+	sizes                           *bool
+	denyNames, denyTypes            *string
+	bagTags                         *string
+	bagMaxLen                       *int
+	ctxTags                         *string
+	load                            loadFlags
+}
+
+// addFlags defines the flags of a traceable invocation in fs.
+func addFlags(fs *flag.FlagSet) flags {
+	return flags{
+		types:     fs.String("types", "", "comma-separated list of type names; must be set"),
+		output:    fs.String("output", "", "output file name; default srcdir/traced_<type>.go"),
+		include:   fs.String("include", "", "regular expression; only trace methods whose Interface.Method name matches"),
+		exclude:   fs.String("exclude", "", "regular expression; do not trace methods whose Interface.Method name matches"),
+		emitTests: fs.Bool("emit-tests", false, "also generate a test for the wrappers next to the output file; requires -output"),
+		fake:      fs.Bool("fake", false, "generate a recording fake of each type instead of a traced wrapper"),
+		notrace:   fs.Bool("notrace", false, "also generate a pass-through wrapper next to the output file that replaces the traced one in builds with the notrace tag; requires -output"),
+		watch:     fs.Bool("watch", false, "keep running and regenerate the output whenever the source files of the packages change; requires -output"),
+		force:     fs.Bool("force", false, "generate the output even if the signatures recorded in it show it is up to date"),
+		interval:  fs.Duration("watch-interval", time.Second, "how often -watch checks the source files for changes"),
+		sizes:     fs.Bool("record-sizes", false, "record the lengths of the slices, maps, strings and channels passed to and returned by traced methods as span tags"),
+		denyNames: fs.String("deny-names", "", "comma-separated list of parameter and field names, in addition to the defaults, whose values must never be tagged"),
+		denyTypes: fs.String("deny-types", "", "comma-separated list of types, in addition to the defaults, whose values must never be tagged, e.g. *example.com/auth.Session"),
+		bagTags:   fs.String("baggage-tags", "", "comma-separated list of baggage keys; record the baggage items of the span of each traced method with these keys as span tags"),
+		bagMaxLen: fs.Int("baggage-max-len", traceable.DefaultBaggageMaxLen, "length in bytes that baggage values set or recorded as tags are truncated to"),
+		ctxTags:   fs.String("context-tags", "", "comma-separated list of key=importpath.Func; record the value the function extracts from the context of each traced method as a span tag"),
+		load:      addLoadFlags(fs),
+	}
+}
 
 func main() {
 	log.SetFlags(0)
 	log.SetPrefix("traceable: ")
-	flag.Parse()
 
-	args := flag.Args()
-	if len(args) == 0 {
-		// Default: process whole package in current directory.
-		args = []string{"."}
+	if len(os.Args) > 1 && os.Args[1] == "gen" {
+		if err := runGen(os.Args[2:]); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
 	}
-	types := strings.Split(*typeNames, ",")
 
-	if err := run(args, types); err != nil {
+	f := addFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := run(f, flag.Args()); err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
-func run(args, types []string) error {
-	g := newGenerator()
+func run(f flags, args []string) error {
+	g, t, err := f.generator()
+	if err != nil {
+		return err
+	}
+	patterns := loadPatterns(g, t, args)
+
+	if *f.watch {
+		return watchPackages(g, patterns, t, *f.interval)
+	}
+
+	if !*f.force && upToDate(g, t, ".") {
+		log.Printf("%s is up to date", t.output)
+		return nil
+	}
 
-	for _, typeName := range types {
-		idx := strings.IndexRune(typeName, '.')
-		if idx != -1 {
-			args = append(args, typeName[:idx])
+	if err := g.ParsePackage(patterns); err != nil {
+		return err
+	}
+	files, err := generate(g, t)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.name == "" {
+			write(file.name, file.src)
+			continue
 		}
+		writeIfChanged(file.name, file.src)
+	}
+
+	return nil
+}
+
+// generator returns the Generator configured by f for the package in the
+// current directory, along with the target it generates.
+func (f flags) generator() (*traceable.Generator, target, error) {
+	switch {
+	case *f.emitTests && *f.output == "":
+		return nil, target{}, errors.New("-emit-tests requires -output to be set")
+	case *f.emitTests && *f.fake:
+		return nil, target{}, errors.New("-emit-tests can not be used with -fake")
+	case *f.notrace && *f.output == "":
+		return nil, target{}, errors.New("-notrace requires -output to be set")
+	case *f.notrace && *f.fake:
+		return nil, target{}, errors.New("-notrace can not be used with -fake")
+	case *f.watch && *f.output == "":
+		return nil, target{}, errors.New("-watch requires -output to be set")
+	case *f.bagMaxLen < 1:
+		return nil, target{}, errors.New("-baggage-max-len must be at least 1")
 	}
 
-	g.ParsePackage(args)
-	g.GenerateAll(types)
+	g := newGenerator(*f.output)
+	g.LoadOptions = f.load.options()
+	g.Deny = traceable.DenyList{Names: splitList(*f.denyNames), Types: splitList(*f.denyTypes)}
+	g.RecordSizes = *f.sizes
+	g.BaggageTags = splitList(*f.bagTags)
+	g.BaggageMaxLen = *f.bagMaxLen
 
-	dst := os.Stdout
-	if len(*output) > 0 {
-		if err := os.MkdirAll(filepath.Dir(*output), os.ModePerm); err != nil {
-			log.Fatalf("unable to create directory: %s", err)
+	var err error
+	if g.ContextTags, err = parseContextTags(splitList(*f.ctxTags)); err != nil {
+		return nil, target{}, err
+	}
+	if g.Include, err = compileFlag("include", *f.include); err != nil {
+		return nil, target{}, err
+	}
+	if g.Exclude, err = compileFlag("exclude", *f.exclude); err != nil {
+		return nil, target{}, err
+	}
+
+	t := target{
+		types:     strings.Split(*f.types, ","),
+		output:    *f.output,
+		emitTests: *f.emitTests,
+		fake:      *f.fake,
+		notrace:   *f.notrace,
+	}
+
+	return g, t, nil
+}
+
+// loadPatterns returns the patterns of the packages g loads to generate t:
+// those given on the command line in args, or the package in the current
+// directory if there are none, and the packages the types of t and the
+// ContextTags of g refer to.
+func loadPatterns(g *traceable.Generator, t target, args []string) []string {
+	patterns := append([]string(nil), args...)
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	patterns = append(patterns, t.packages()...)
+
+	return append(patterns, g.ContextTagPackages()...)
+}
+
+// target describes the files generated for a set of types.
+type target struct {
+	types     []string
+	output    string
+	emitTests bool
+	fake      bool
+	notrace   bool
+}
+
+// packages returns the import paths of the packages of the types of t that
+// are qualified by one, e.g. io for io.Reader, which must be loaded along
+// with the root package.
+func (t target) packages() []string {
+	var paths []string
+	for _, typeName := range t.types {
+		if idx := strings.IndexRune(typeName, '.'); idx != -1 {
+			paths = append(paths, typeName[:idx])
 		}
-		f, err := os.Create(*output)
-		if err != nil {
-			log.Fatalf("failed opening destination file: %s", err)
+	}
+
+	return paths
+}
+
+// files returns the names of the files generated for t.
+func (t target) files() []string {
+	names := []string{t.output}
+	if t.emitTests {
+		names = append(names, testFileName(t.output))
+	}
+	if t.notrace {
+		names = append(names, noTraceFileName(t.output))
+	}
+
+	return names
+}
+
+// upToDate reports whether the files generated for t were generated from
+// the current source of the interfaces in dir, in which case loading the
+// packages can be skipped.
+func upToDate(g *traceable.Generator, t target, dir string) bool {
+	if t.output == "" {
+		return false
+	}
+
+	for _, name := range t.files() {
+		if !g.UpToDate(name, dir, t.types) {
+			return false
 		}
-		defer f.Close()
-		dst = f
 	}
 
-	if _, err := dst.Write(g.Format()); err != nil {
+	return true
+}
+
+// generatedFile is a file output by traceable.
+type generatedFile struct {
+	name string
+	src  []byte
+}
+
+// generate generates the files for t from the packages loaded into g.
+func generate(g *traceable.Generator, t target) ([]generatedFile, error) {
+	g.Reset()
+	g.NoTrace = t.notrace
+	if t.fake {
+		if err := g.GenerateAllFakes(t.types); err != nil {
+			return nil, err
+		}
+		return []generatedFile{{t.output, g.Format()}}, nil
+	}
+
+	if err := g.GenerateAll(t.types); err != nil {
+		return nil, err
+	}
+	files := []generatedFile{{t.output, g.Format()}}
+
+	if t.emitTests {
+		g.Reset()
+		if err := g.GenerateAllTests(t.types); err != nil {
+			return nil, err
+		}
+		files = append(files, generatedFile{testFileName(t.output), g.Format()})
+	}
+
+	if t.notrace {
+		g.Reset()
+		if err := g.GenerateAllNoTrace(t.types); err != nil {
+			return nil, err
+		}
+		files = append(files, generatedFile{noTraceFileName(t.output), g.Format()})
+	}
+
+	return files, nil
+}
+
+// write writes src to the named file, or to stdout if name is empty.
+func write(name string, src []byte) {
+	if len(name) > 0 {
+		if err := writeFile(name, src); err != nil {
+			log.Fatalf("writing output: %s", err)
+		}
+		return
+	}
+
+	if _, err := os.Stdout.Write(src); err != nil {
 		log.Fatalf("writing output: %s", err)
 	}
+}
 
-	return nil
+// writeIfChanged writes src to the named file unless it already has those
+// contents, reporting whether it was written.
+func writeIfChanged(name string, src []byte) bool {
+	if existing, err := ioutil.ReadFile(name); err == nil && bytes.Equal(existing, src) {
+		return false
+	}
+
+	write(name, src)
+	return true
+}
+
+// writeFile writes src to the named file atomically, by writing it to a
+// temporary file in the same directory and renaming that over name, so that
+// readers never see a partially written file.
+func writeFile(name string, src []byte) error {
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("unable to create directory: %w", err)
+	}
+
+	f, err := ioutil.TempFile(dir, "."+filepath.Base(name)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(src); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), name)
+}
+
+// testFileName returns the name of the test file generated alongside the
+// output file name, e.g. traced/foo_test.go for traced/foo.go.
+func testFileName(name string) string {
+	return strings.TrimSuffix(name, ".go") + "_test.go"
 }
 
-func newGenerator() traceable.Generator {
+// noTraceFileName returns the name of the pass-through wrapper generated
+// alongside the output file name, e.g. traced/foo_notrace.go for
+// traced/foo.go.
+func noTraceFileName(name string) string {
+	return strings.TrimSuffix(name, ".go") + "_notrace.go"
+}
+
+// newGenerator returns a Generator for the package in the current
+// directory, writing to the file output.
+func newGenerator(output string) *traceable.Generator {
 	var g traceable.Generator
 
-	dstPath, err := filepath.Abs(filepath.Dir(*output))
+	dstPath, err := filepath.Abs(filepath.Dir(output))
 	if err != nil {
 		log.Println("unable to determine destination file path:", err)
 	}
@@ -87,7 +350,102 @@ func newGenerator() traceable.Generator {
 	g.OutputPackagePath = pkgPath
 	g.RootPackage = getRootPackage()
 
-	return g
+	return &g
+}
+
+// splitList splits a comma-separated list, returning nil if it is empty.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// parseContextTags parses a list of context tags written as
+// key=importpath.Func.
+func parseContextTags(list []string) ([]traceable.ContextTag, error) {
+	var tags []traceable.ContextTag
+	for _, s := range list {
+		tag, err := traceable.ParseContextTag(s)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// loadFlags are the flags controlling how the packages are loaded.
+type loadFlags struct {
+	tags, mod, goos, goarch *string
+	tests                   *bool
+}
+
+// addLoadFlags defines the flags controlling how the packages are loaded in
+// fs.
+func addLoadFlags(fs *flag.FlagSet) loadFlags {
+	return loadFlags{
+		tags:   fs.String("tags", "", "comma-separated list of additional build tags to consider satisfied when loading the packages"),
+		mod:    fs.String("mod", "", "module download mode passed to the go command when loading the packages, e.g. mod or vendor"),
+		goos:   fs.String("goos", "", "GOOS to load the packages for; default the current platform"),
+		goarch: fs.String("goarch", "", "GOARCH to load the packages for; default the current platform"),
+		tests:  fs.Bool("tests", false, "also load the _test.go files of the packages, so that interfaces declared in tests can be used"),
+	}
+}
+
+// options returns the LoadOptions set by the flags.
+func (f loadFlags) options() traceable.LoadOptions {
+	return traceable.LoadOptions{
+		// Like the go command, accept tags separated by spaces too.
+		Tags: strings.FieldsFunc(*f.tags, func(r rune) bool {
+			return r == ',' || r == ' '
+		}),
+		Mod:    *f.mod,
+		GOOS:   *f.goos,
+		GOARCH: *f.goarch,
+		Tests:  *f.tests,
+	}
+}
+
+// args returns the flags that were set, as they would be passed to
+// traceable.
+func (f loadFlags) args() []string {
+	var args []string
+	for _, fl := range []struct{ name, value string }{
+		{"tags", *f.tags},
+		{"mod", *f.mod},
+		{"goos", *f.goos},
+		{"goarch", *f.goarch},
+	} {
+		if fl.value != "" {
+			args = append(args, "-"+fl.name, fl.value)
+		}
+	}
+	if *f.tests {
+		args = append(args, "-tests")
+	}
+
+	return args
+}
+
+// compileFlag compiles the regular expression given to the named flag,
+// returning nil when the flag was not set.
+func compileFlag(name, expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -%s expression: %w", name, err)
+	}
+
+	return re, nil
 }
 
 func getRootPackage() string {
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ConorNevin/traceable"
+)
+
+// watchPackages generates the output for t and then regenerates it
+// whenever the source files of the packages matching patterns change, as
+// checked every interval. It only returns if the packages can not be loaded
+// initially.
+func watchPackages(g *traceable.Generator, patterns []string, t target, interval time.Duration) error {
+	w, err := traceable.NewWatcher(g, patterns)
+	if err != nil {
+		return err
+	}
+
+	regenerate(g, w, t, nil)
+	for {
+		time.Sleep(interval)
+
+		reloaded, err := w.Changed()
+		if err != nil {
+			log.Println(err)
+		}
+		if affected(g, t.types, reloaded) {
+			regenerate(g, w, t, reloaded)
+		}
+	}
+}
+
+// affected reports whether any of the types are declared in one of the
+// reloaded packages.
+func affected(g *traceable.Generator, types, reloaded []string) bool {
+	for _, typeName := range types {
+		pkg := g.RootPackage
+		if idx := strings.LastIndex(typeName, "."); idx != -1 {
+			pkg = typeName[:idx]
+		}
+		for _, path := range reloaded {
+			if path == pkg {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// regenerate generates the output for t, writing the files whose contents
+// changed, and logs a summary. The files written are reported to w so that
+// they do not trigger another reload. If the output can not be generated, e.g.
+// because an interface is half-written, the error is logged and the files
+// are left as they are until the source is fixed.
+func regenerate(g *traceable.Generator, w *traceable.Watcher, t target, reloaded []string) {
+	start := time.Now()
+
+	files, err := generate(g, t)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	var written, unchanged []string
+	for _, f := range files {
+		if writeIfChanged(f.name, f.src) {
+			written = append(written, f.name)
+			if err := w.Wrote(f.name); err != nil {
+				log.Println(err)
+			}
+		} else {
+			unchanged = append(unchanged, f.name)
+		}
+	}
+
+	var summary []string
+	if len(reloaded) > 0 {
+		summary = append(summary, "reloaded "+strings.Join(reloaded, ", "))
+	}
+	if len(written) > 0 {
+		summary = append(summary, "wrote "+strings.Join(written, ", "))
+	}
+	if len(unchanged) > 0 {
+		summary = append(summary, strings.Join(unchanged, ", ")+" unchanged")
+	}
+	log.Printf("%s in %s", strings.Join(summary, "; "), time.Since(start).Round(time.Millisecond))
+}
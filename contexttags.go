@@ -0,0 +1,147 @@
+package traceable
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// ContextTag records the value returned by a function of the context passed
+// to each traced method as a tag on its span.
+type ContextTag struct {
+	// Key is the key of the tag.
+	Key string
+	// Func is the extractor function, qualified by the import path of its
+	// package, e.g. github.com/acme/auth.TenantFromContext. It must accept a
+	// single context.Context and return the value, optionally followed by a
+	// bool reporting whether the context has one.
+	Func string
+}
+
+// ParseContextTag parses a ContextTag written as key=importpath.Func.
+func ParseContextTag(s string) (ContextTag, error) {
+	idx := strings.IndexRune(s, '=')
+	if idx == -1 {
+		return ContextTag{}, fmt.Errorf("context tag %q must be written as key=importpath.Func", s)
+	}
+	key, fn := strings.TrimSpace(s[:idx]), strings.TrimSpace(s[idx+1:])
+	dot := strings.LastIndex(fn, ".")
+	if key == "" || dot <= 0 || dot == len(fn)-1 || strings.LastIndex(fn, "/") > dot {
+		return ContextTag{}, fmt.Errorf("context tag %q must be written as key=importpath.Func", s)
+	}
+
+	return ContextTag{Key: key, Func: fn}, nil
+}
+
+// split returns the import path of the package of the extractor function and
+// its name.
+func (c ContextTag) split() (pkg, name string) {
+	idx := strings.LastIndex(c.Func, ".")
+	return c.Func[:idx], c.Func[idx+1:]
+}
+
+// ContextTagPackages returns the import paths of the packages declaring the
+// extractor functions of the ContextTags, which must be loaded along with
+// the root package.
+func (g *Generator) ContextTagPackages() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, c := range g.ContextTags {
+		if pkg, _ := c.split(); !seen[pkg] {
+			seen[pkg] = true
+			paths = append(paths, pkg)
+		}
+	}
+
+	return paths
+}
+
+// extractor is a ContextTag whose function has been checked.
+type extractor struct {
+	key  string
+	pkg  string
+	name string
+	// ok is set if the function also returns whether the context has a
+	// value.
+	ok bool
+}
+
+// checkContextTags checks the signatures of the extractor functions of the
+// ContextTags, returning them if they can be called from outputPackage.
+func (g *Generator) checkContextTags(outputPackage string) ([]extractor, error) {
+	var extractors []extractor
+	for _, c := range g.ContextTags {
+		path, name := c.split()
+		pkg, ok := g.pkgs[path]
+		if !ok || pkg.types == nil {
+			return nil, fmt.Errorf("package %s, of the extractor for the context tag %s, was not loaded", path, c.Key)
+		}
+		fn, ok := pkg.types.Scope().Lookup(name).(*types.Func)
+		if !ok {
+			return nil, fmt.Errorf("%s, the extractor for the context tag %s, is not a function", c.Func, c.Key)
+		}
+		if !token.IsExported(name) && path != outputPackage {
+			return nil, fmt.Errorf("%s, the extractor for the context tag %s, is unexported and can only be called in package %s", c.Func, c.Key, path)
+		}
+
+		sig := fn.Type().(*types.Signature)
+		params, results := sig.Params(), sig.Results()
+		switch {
+		case hasTypeParams(sig):
+			return nil, fmt.Errorf("%s, the extractor for the context tag %s, has type parameters", c.Func, c.Key)
+		case params.Len() != 1 || !isContextType(params.At(0).Type()):
+			return nil, fmt.Errorf("%s, the extractor for the context tag %s, must accept a single context.Context, not %s", c.Func, c.Key, types.TypeString(params, nil))
+		case results.Len() == 2 && types.Identical(results.At(1).Type(), types.Typ[types.Bool]):
+		case results.Len() != 1:
+			return nil, fmt.Errorf("%s, the extractor for the context tag %s, must return a value, optionally followed by a bool, not %s", c.Func, c.Key, types.TypeString(results, nil))
+		}
+
+		value := results.At(0).Type()
+		if denied := g.deniedName(c.Key); denied != "" {
+			return nil, fmt.Errorf("refusing to tag the context tag %s: the name %s matches %q in the deny-list", c.Key, c.Key, denied)
+		}
+		if denied := g.deniedType(value); denied != "" {
+			return nil, fmt.Errorf("refusing to tag the context tag %s: its type %s matches %s in the deny-list", c.Key, types.TypeString(value, nil), denied)
+		}
+
+		extractors = append(extractors, extractor{key: c.Key, pkg: path, name: name, ok: results.Len() == 2})
+	}
+
+	return extractors, nil
+}
+
+// printContextTags prints the calls recording the values extracted from the
+// context of m on span.
+func (g *Generator) printContextTags(m Method) {
+	rt := g.importName(runtimePackagePath)
+	for _, e := range g.extractors {
+		fn := e.name
+		if e.pkg != g.OutputPackagePath {
+			fn = g.importName(e.pkg) + "." + e.name
+		}
+
+		if e.ok {
+			g.Printf("if v, ok := %s(%s); ok {\n", fn, m.contextArg())
+			g.Printf("%s.Tag(span, %q, v)\n", rt, e.key)
+			g.Printf("}\n")
+			continue
+		}
+		g.Printf("%s.Tag(span, %q, %s(%s))\n", rt, e.key, fn, m.contextArg())
+	}
+}
+
+// contextTagImports returns the import paths of the packages of the
+// extractors that generated code calls.
+func (g *Generator) contextTagImports() []string {
+	if !g.tracesAny() {
+		return nil
+	}
+
+	var paths []string
+	for _, e := range g.extractors {
+		paths = append(paths, e.pkg)
+	}
+
+	return paths
+}
@@ -0,0 +1,152 @@
+package traceable
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseContextTag(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ContextTag
+		wantErr bool
+	}{
+		{in: "tenant.id=github.com/acme/auth.TenantFromContext", want: ContextTag{Key: "tenant.id", Func: "github.com/acme/auth.TenantFromContext"}},
+		{in: " tenant.id = auth.Tenant ", want: ContextTag{Key: "tenant.id", Func: "auth.Tenant"}},
+		{in: "github.com/acme/auth.TenantFromContext", wantErr: true},
+		{in: "=github.com/acme/auth.TenantFromContext", wantErr: true},
+		{in: "tenant.id=TenantFromContext", wantErr: true},
+		{in: "tenant.id=github.com/acme/auth.", wantErr: true},
+		{in: "tenant.id=github.com/acme.com/auth", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseContextTag(tt.in)
+			if tt.wantErr {
+				qt.Check(t, err, qt.ErrorMatches, `context tag .* must be written as key=importpath.Func`)
+				return
+			}
+			qt.Assert(t, err, qt.IsNil)
+			qt.Check(t, got, qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestGenerator_checkContextTags(t *testing.T) {
+	const source = `package store
+
+import (
+	"context"
+	"crypto/rsa"
+)
+
+var Value = 1
+
+func Tenant(ctx context.Context) (string, bool) { return "", false }
+func RequestID(ctx context.Context) string { return "" }
+func requestID(ctx context.Context) string { return "" }
+func Key(ctx context.Context) *rsa.PrivateKey { return nil }
+func Lookup(ctx context.Context, key string) string { return "" }
+func Count(ctx context.Context) (int, error) { return 0, nil }
+func Nothing(ctx context.Context) {}
+`
+
+	tests := []struct {
+		name    string
+		tag     string
+		output  string
+		want    extractor
+		wantErr string
+	}{
+		{
+			name: "optional value",
+			tag:  "tenant.id=example.com/store.Tenant",
+			want: extractor{key: "tenant.id", pkg: "example.com/store", name: "Tenant", ok: true},
+		},
+		{
+			name: "value",
+			tag:  "request.id=example.com/store.RequestID",
+			want: extractor{key: "request.id", pkg: "example.com/store", name: "RequestID"},
+		},
+		{
+			name:   "unexported in the same package",
+			tag:    "request.id=example.com/store.requestID",
+			output: "example.com/store",
+			want:   extractor{key: "request.id", pkg: "example.com/store", name: "requestID"},
+		},
+		{
+			name:    "unexported in another package",
+			tag:     "request.id=example.com/store.requestID",
+			wantErr: `example.com/store.requestID, the extractor for the context tag request.id, is unexported and can only be called in package example.com/store`,
+		},
+		{
+			name:    "package not loaded",
+			tag:     "tenant.id=example.com/auth.Tenant",
+			wantErr: `package example.com/auth, of the extractor for the context tag tenant.id, was not loaded`,
+		},
+		{
+			name:    "not a function",
+			tag:     "value=example.com/store.Value",
+			wantErr: `example.com/store.Value, the extractor for the context tag value, is not a function`,
+		},
+		{
+			name:    "parameters",
+			tag:     "value=example.com/store.Lookup",
+			wantErr: `example.com/store.Lookup, the extractor for the context tag value, must accept a single context.Context, not \(ctx context.Context, key string\)`,
+		},
+		{
+			name:    "second result",
+			tag:     "count=example.com/store.Count",
+			wantErr: `example.com/store.Count, the extractor for the context tag count, must return a value, optionally followed by a bool, not \(int, error\)`,
+		},
+		{
+			name:    "no result",
+			tag:     "nothing=example.com/store.Nothing",
+			wantErr: `example.com/store.Nothing, the extractor for the context tag nothing, must return a value, optionally followed by a bool, not \(\)`,
+		},
+		{
+			name:    "denied name",
+			tag:     "auth.token=example.com/store.RequestID",
+			wantErr: `refusing to tag the context tag auth.token: the name auth.token matches "token" in the deny-list`,
+		},
+		{
+			name:    "denied type",
+			tag:     "key=example.com/store.Key",
+			wantErr: `refusing to tag the context tag key: its type \*crypto/rsa.PrivateKey matches \*rsa.PrivateKey in the deny-list`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := ParseContextTag(tt.tag)
+			qt.Assert(t, err, qt.IsNil)
+
+			g := &Generator{
+				pkgs:        map[string]*Package{"example.com/store": parseSource(t, source)},
+				ContextTags: []ContextTag{tag},
+			}
+			output := tt.output
+			if output == "" {
+				output = "example.com/traced"
+			}
+
+			got, err := g.checkContextTags(output)
+			if tt.wantErr != "" {
+				qt.Check(t, err, qt.ErrorMatches, tt.wantErr)
+				return
+			}
+			qt.Assert(t, err, qt.IsNil)
+			qt.Assert(t, got, qt.HasLen, 1)
+			qt.Check(t, got[0], qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestGenerator_ContextTagPackages(t *testing.T) {
+	g := &Generator{ContextTags: []ContextTag{
+		{Key: "tenant.id", Func: "github.com/acme/auth.Tenant"},
+		{Key: "user.id", Func: "github.com/acme/auth.User"},
+		{Key: "request.id", Func: "github.com/acme/requests.ID"},
+	}}
+	qt.Check(t, g.ContextTagPackages(), qt.DeepEquals, []string{"github.com/acme/auth", "github.com/acme/requests"})
+}
@@ -0,0 +1,41 @@
+package traceable
+
+import (
+	"go/ast"
+	"strings"
+)
+
+const directivePrefix = "//traceable:"
+
+// directives holds the //traceable: comments attached to a declaration, keyed
+// by directive name. The value is the remainder of the comment line.
+type directives map[string]string
+
+func parseDirectives(groups ...*ast.CommentGroup) directives {
+	d := make(directives)
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+
+		for _, c := range g.List {
+			if !strings.HasPrefix(c.Text, directivePrefix) {
+				continue
+			}
+
+			text := strings.TrimPrefix(c.Text, directivePrefix)
+			name, value := text, ""
+			if idx := strings.IndexAny(text, " \t"); idx != -1 {
+				name, value = text[:idx], strings.TrimSpace(text[idx+1:])
+			}
+			d[name] = value
+		}
+	}
+
+	return d
+}
+
+func (d directives) has(name string) bool {
+	_, ok := d[name]
+	return ok
+}
@@ -0,0 +1,53 @@
+package traceable
+
+import (
+	"go/ast"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func Test_parseDirectives(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []string
+		want     directives
+	}{
+		{
+			name:     "no comments",
+			comments: nil,
+			want:     directives{},
+		},
+		{
+			name:     "ignores regular comments",
+			comments: []string{"// Get returns the value for key."},
+			want:     directives{},
+		},
+		{
+			name:     "directive without a value",
+			comments: []string{"//traceable:skip"},
+			want:     directives{"skip": ""},
+		},
+		{
+			name:     "directive with a value",
+			comments: []string{"// Get returns the value for key.", "//traceable:kind  client "},
+			want:     directives{"kind": "client"},
+		},
+		{
+			name:     "requires the directive to start the comment",
+			comments: []string{"// traceable:skip"},
+			want:     directives{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &ast.CommentGroup{}
+			for _, c := range tt.comments {
+				g.List = append(g.List, &ast.Comment{Text: c})
+			}
+
+			qt.Check(t, parseDirectives(g, nil), qt.DeepEquals, tt.want)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package traceable
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// fakeTB is the type of the test passed to the assertions of fakes. It is
+// implemented by testing.TB, without the fakes, which need not be in test
+// files, importing the testing package into the packages that declare them.
+const fakeTB = "interface {\nHelper()\nErrorf(format string, args ...interface{})\n}"
+
+// GenerateAllFakes generates fakes for each of the types.
+func (g *Generator) GenerateAllFakes(types []string) error {
+	for _, t := range types {
+		if err := g.GenerateFake(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GenerateFake generates FakeX, a test double for the interface typeName.
+// FakeX records the calls made to each method, returns values configured
+// with XReturns or delegates to an XFunc, and can assert how many times each
+// method was called.
+func (g *Generator) GenerateFake(typeName string) error {
+	log.Printf("generating fake for %s", typeName)
+
+	if err := g.loadInterface(typeName); err != nil {
+		return err
+	}
+	g.sortMethods()
+	if err := g.checkFakeNames(typeName); err != nil {
+		return err
+	}
+
+	g.printHeader(typeName, g.fileConstraint(false))
+	g.printImports(syncPackagePath)
+	g.printFakeStruct(typeName)
+	for _, m := range g.Interface.methods {
+		g.printFakeMethod(typeName, m)
+	}
+
+	return nil
+}
+
+func (g *Generator) printFakeStruct(typeName string) {
+	structName := getStructName(typeName)
+	fakeName := "Fake" + structName
+
+	g.Printf("// %s is a fake implementation of %s that records the calls made to it.\n", fakeName, typeName)
+	g.Printf("type %s struct {\n", fakeName)
+	g.Printf("mu %s.Mutex\n", g.importName(syncPackagePath))
+	for _, m := range g.Interface.methods {
+		g.Printf("\n")
+		g.Printf("// %sFunc, if set, is called by %s.\n", m.name, m.name)
+		g.Printf("%sFunc func(%s) %s\n", m.name, strings.Join(g.funcParams(m), ","), g.results(m, false))
+		g.Printf("%s []%s\n", fakeField(m, "calls"), fakeCallName(structName, m))
+		if len(m.returns) > 0 {
+			g.Printf("%s struct {\n", fakeField(m, "returns"))
+			for i, r := range m.returns {
+				g.Printf("%s %s\n", resultName(i), g.typeString(r))
+			}
+			g.Printf("}\n")
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("var _ %s = (*%s)(nil)\n", g.interfaceName(typeName), fakeName)
+
+	for _, m := range g.Interface.methods {
+		g.Printf("\n")
+		g.Printf("// %s holds the arguments of a call to %s.%s.\n", fakeCallName(structName, m), fakeName, m.name)
+		g.Printf("type %s struct {\n", fakeCallName(structName, m))
+		for i, a := range m.args {
+			g.Printf("A%d %s\n", i, g.typeString(a))
+		}
+		g.Printf("}\n")
+	}
+}
+
+func (g *Generator) printFakeMethod(typeName string, m Method) {
+	structName := getStructName(typeName)
+	fakeName := "Fake" + structName
+	callName := fakeCallName(structName, m)
+	params, args := g.params(m)
+
+	fields := make([]string, len(m.args))
+	for i := range m.args {
+		fields[i] = "A" + strconv.Itoa(i) + ": a" + strconv.Itoa(i)
+	}
+
+	g.Printf("\n")
+	g.Printf("// %s implements %s.\n", m.name, typeName)
+	g.Printf("func (f *%s) %s(%s) %s {\n", fakeName, m.name, strings.Join(params, ","), g.results(m, false))
+	g.Printf("f.mu.Lock()\n")
+	g.Printf("f.%s = append(f.%[1]s, %s{%s})\n", fakeField(m, "calls"), callName, strings.Join(fields, ","))
+	g.Printf("fn := f.%sFunc\n", m.name)
+	if len(m.returns) > 0 {
+		g.Printf("returns := f.%s\n", fakeField(m, "returns"))
+	}
+	g.Printf("f.mu.Unlock()\n")
+	g.Printf("\n")
+	g.Printf("if fn != nil {\n")
+	if len(m.returns) > 0 {
+		g.Printf("return fn(%s)\n", strings.Join(args, ","))
+	} else {
+		g.Printf("fn(%s)\n", strings.Join(args, ","))
+	}
+	g.Printf("}\n")
+	if len(m.returns) > 0 {
+		results := make([]string, len(m.returns))
+		for i := range m.returns {
+			results[i] = "returns." + resultName(i)
+		}
+		g.Printf("return %s\n", strings.Join(results, ","))
+	}
+	g.Printf("}\n")
+
+	if len(m.returns) > 0 {
+		assign := make([]string, len(m.returns))
+		for i := range m.returns {
+			assign[i] = "f." + fakeField(m, "returns") + "." + resultName(i)
+		}
+		results := make([]string, len(m.returns))
+		for i := range m.returns {
+			results[i] = resultName(i)
+		}
+
+		g.Printf("\n")
+		g.Printf("// %sReturns sets the values returned by %s when %sFunc is not set.\n", m.name, m.name, m.name)
+		g.Printf("func (f *%s) %sReturns%s {\n", fakeName, m.name, g.results(m, true))
+		g.Printf("f.mu.Lock()\n")
+		g.Printf("defer f.mu.Unlock()\n")
+		g.Printf("%s = %s\n", strings.Join(assign, ","), strings.Join(results, ","))
+		g.Printf("}\n")
+	}
+
+	g.Printf("\n")
+	g.Printf("// %sCalls returns the arguments of each call made to %s.\n", m.name, m.name)
+	g.Printf("func (f *%s) %sCalls() []%s {\n", fakeName, m.name, callName)
+	g.Printf("f.mu.Lock()\n")
+	g.Printf("defer f.mu.Unlock()\n")
+	g.Printf("return append([]%s(nil), f.%s...)\n", callName, fakeField(m, "calls"))
+	g.Printf("}\n")
+
+	g.Printf("\n")
+	g.Printf("// %sCallCount returns the number of calls made to %s.\n", m.name, m.name)
+	g.Printf("func (f *%s) %sCallCount() int {\n", fakeName, m.name)
+	g.Printf("f.mu.Lock()\n")
+	g.Printf("defer f.mu.Unlock()\n")
+	g.Printf("return len(f.%s)\n", fakeField(m, "calls"))
+	g.Printf("}\n")
+
+	g.Printf("\n")
+	g.Printf("// Assert%sCallCount fails the test unless %s was called want times.\n", m.name, m.name)
+	g.Printf("func (f *%s) Assert%sCallCount(t %s, want int) {\n", fakeName, m.name, fakeTB)
+	g.Printf("t.Helper()\n")
+	g.Printf("if got := f.%sCallCount(); got != want {\n", m.name)
+	g.Printf("t.Errorf(\"expected %s.%s to be called %%d times, got %%d\", want, got)\n", fakeName, m.name)
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// funcParams returns the parameter types of m as they are written in a func
+// type.
+// checkFakeNames checks that the fields, methods and types generated for the
+// fake of typeName do not clash with each other or with the methods of the
+// Interface, such as GetCalls, which is generated for Get.
+func (g *Generator) checkFakeNames(typeName string) error {
+	structName := getStructName(typeName)
+	members := map[string]string{"mu": "its mutex"}
+	for _, m := range g.Interface.methods {
+		members[m.name] = "the method " + m.name
+	}
+	callTypes := make(map[string]string)
+
+	add := func(names map[string]string, name, m string) error {
+		if other, ok := names[name]; ok {
+			return fmt.Errorf("can not generate a fake for %s: %s, generated for %s, clashes with %s", typeName, name, m, other)
+		}
+		names[name] = name + ", generated for " + m
+		return nil
+	}
+	for _, m := range g.Interface.methods {
+		generated := []string{m.name + "Func", fakeField(m, "calls"), m.name + "Calls", m.name + "CallCount", "Assert" + m.name + "CallCount"}
+		if len(m.returns) > 0 {
+			generated = append(generated, fakeField(m, "returns"), m.name+"Returns")
+		}
+		for _, name := range generated {
+			if err := add(members, name, m.name); err != nil {
+				return err
+			}
+		}
+		if err := add(callTypes, fakeCallName(structName, m), m.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) funcParams(m Method) []string {
+	params, _ := g.params(m)
+	for i, p := range params {
+		params[i] = p[strings.IndexRune(p, ' ')+1:]
+	}
+
+	return params
+}
+
+// fakeCallName returns the name of the type holding the arguments of a call
+// to m on the fake of the interface structName.
+func fakeCallName(structName string, m Method) string {
+	return "Fake" + structName + upperFirst(m.name) + "Call"
+}
+
+// fakeField returns the name of the unexported field of a fake holding the
+// state named prefix for m. The prefix comes first so that the field can not
+// collide with the methods generated for an unexported method.
+func fakeField(m Method, prefix string) string {
+	return prefix + upperFirst(m.name)
+}
+
+func upperFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
@@ -0,0 +1,126 @@
+package traceable
+
+import (
+	"go/types"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func Test_fakeField(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		want   string
+	}{
+		{"exported method", "Get", "callsGet"},
+		{"unexported method", "get", "callsGet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qt.Check(t, fakeField(Method{name: tt.method}, "calls"), qt.Equals, tt.want)
+		})
+	}
+}
+
+func Test_Generator_funcParams(t *testing.T) {
+	m := Method{
+		args: []types.Type{
+			newContextType(),
+			types.NewSlice(types.Typ[types.String]),
+		},
+		isVariadic: true,
+	}
+
+	g := &Generator{}
+	qt.Check(t, g.funcParams(m), qt.DeepEquals, []string{"context.Context", "...string"})
+}
+
+func TestGenerator_GenerateFake(t *testing.T) {
+	g := &Generator{
+		packageMap: map[string]string{},
+		pkgs: map[string]*Package{
+			"root/cache": {
+				interfaces: []*Interface{
+					{
+						name: "Cache",
+						methods: []Method{
+							{
+								name:    "Set",
+								args:    []types.Type{newContextType()},
+								returns: []types.Type{newErrorType()},
+							},
+							{name: "Flush"},
+						},
+					},
+				},
+			},
+		},
+		RootPackage:       "root/cache",
+		OutputPackagePath: "root/cache",
+	}
+	qt.Assert(t, g.GenerateFake("Cache"), qt.IsNil)
+
+	out := string(g.Format())
+	qt.Check(t, out, qt.Contains, "type FakeCache struct {")
+	qt.Check(t, out, qt.Contains, "var _ Cache = (*FakeCache)(nil)")
+	qt.Check(t, out, qt.Contains, "SetFunc    func(context.Context) error")
+	qt.Check(t, out, qt.Contains, "func (f *FakeCache) SetReturns(r0 error) {")
+	qt.Check(t, out, qt.Contains, "func (f *FakeCache) AssertSetCallCount(t interface {\n\tHelper()\n\tErrorf(format string, args ...interface{})\n}, want int) {")
+	qt.Check(t, out, qt.Not(qt.Contains), `"testing"`)
+	qt.Check(t, out, qt.Contains, "func (f *FakeCache) Flush() {")
+	qt.Check(t, out, qt.Not(qt.Contains), "FlushReturns")
+}
+
+func TestGenerator_GenerateFake_clash(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []Method
+		wantErr string
+	}{
+		{
+			name:    "calls",
+			methods: []Method{{name: "Get"}, {name: "GetCalls"}},
+			wantErr: "can not generate a fake for Store: GetCalls, generated for Get, clashes with the method GetCalls",
+		},
+		{
+			name:    "func",
+			methods: []Method{{name: "Search"}, {name: "SearchFunc"}},
+			wantErr: "can not generate a fake for Store: SearchFunc, generated for Search, clashes with the method SearchFunc",
+		},
+		{
+			name:    "returns",
+			methods: []Method{{name: "Get", returns: []types.Type{newErrorType()}}, {name: "GetReturns"}},
+			wantErr: "can not generate a fake for Store: GetReturns, generated for Get, clashes with the method GetReturns",
+		},
+		{
+			name:    "generated",
+			methods: []Method{{name: "Get"}, {name: "AssertGet"}},
+			wantErr: "can not generate a fake for Store: AssertGetCallCount, generated for Get, clashes with AssertGetCallCount, generated for AssertGet",
+		},
+		{
+			name:    "no returns",
+			methods: []Method{{name: "Get"}, {name: "GetReturns"}},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := &Generator{
+				packageMap: map[string]string{},
+				pkgs: map[string]*Package{
+					"root/store": {interfaces: []*Interface{{name: "Store", methods: test.methods}}},
+				},
+				RootPackage:       "root/store",
+				OutputPackagePath: "root/store",
+			}
+			err := g.GenerateFake("Store")
+			if test.wantErr == "" {
+				qt.Assert(t, err, qt.IsNil)
+				return
+			}
+			qt.Assert(t, err, qt.ErrorMatches, test.wantErr)
+		})
+	}
+}
@@ -4,31 +4,107 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/build/constraint"
+	"go/token"
 	"go/types"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/imports"
 )
 
 const (
+	runtimePackagePath = "github.com/ConorNevin/traceable/runtime"
+	runtimePackageName = "runtime"
+	optionPackagePath  = "github.com/ConorNevin/traceable/runtime/option"
+	optionPackageName  = "option"
+
+	// runtimeVersion is the version of the runtime package API that
+	// generated code requires.
+	runtimeVersion = 7
+
+	contextPackagePath = "context"
+	contextPackageName = "context"
+
+	syncPackagePath        = "sync"
+	syncPackageName        = "sync"
+	testingPackagePath     = "testing"
+	testingPackageName     = "testing"
 	openTracingPackagePath = "github.com/opentracing/opentracing-go"
 	openTracingPackageName = "opentracing"
+	mockTracerPackagePath  = "github.com/opentracing/opentracing-go/mocktracer"
+	mockTracerPackageName  = "mocktracer"
 )
 
+// generatedPackages are the names of the packages that generated code
+// refers to.
+var generatedPackages = map[string]string{
+	runtimePackagePath:     runtimePackageName,
+	optionPackagePath:      optionPackageName,
+	contextPackagePath:     contextPackageName,
+	syncPackagePath:        syncPackageName,
+	testingPackagePath:     testingPackageName,
+	openTracingPackagePath: openTracingPackageName,
+	mockTracerPackagePath:  mockTracerPackageName,
+	extPackagePath:         extPackageName,
+}
+
 type Generator struct {
 	buf        bytes.Buffer
 	pkgs       map[string]*Package
 	packageMap map[string]string
+	imports    importSet
 
 	RootPackage       string
 	OutputPackagePath string
 	Interface         Interface
+
+	// Args are the arguments traceable was run with, which are recorded in
+	// the header of generated files. If nil, os.Args[1:] is used.
+	Args []string
+
+	// Include, if set, restricts tracing to methods whose "Interface.Method"
+	// name matches the expression.
+	Include *regexp.Regexp
+	// Exclude, if set, disables tracing for methods whose "Interface.Method"
+	// name matches the expression.
+	Exclude *regexp.Regexp
+
+	// NoTrace, if set, constrains the traced wrappers and their tests to
+	// builds without the notrace tag, for use along with the pass-through
+	// wrappers generated by GenerateNoTrace.
+	NoTrace bool
+
+	// Deny lists values that must never be recorded on spans, in addition
+	// to DefaultDenyList.
+	Deny DenyList
+
+	// RecordSizes, if set, records the lengths of the slices, maps, strings
+	// and channels passed to and returned by traced methods on their spans.
+	RecordSizes bool
+
+	// ContextTags are recorded on the span of every traced method from the
+	// method's context.
+	ContextTags []ContextTag
+	extractors  []extractor
+
+	// BaggageTags are the keys of the baggage items that are recorded as
+	// tags on the span of every traced method.
+	BaggageTags []string
+	// BaggageMaxLen is the length, in bytes, that baggage values set or
+	// recorded as tags are truncated to. If 0, DefaultBaggageMaxLen is used.
+	BaggageMaxLen int
+
+	// LoadOptions control how ParsePackage loads packages.
+	LoadOptions
 }
 
 type Package struct {
@@ -36,29 +112,61 @@ type Package struct {
 	importPath string
 	interfaces []*Interface
 	imports    []*types.Package
+	// aliases are the names the package's files import packages under,
+	// keyed by import path.
+	aliases map[string]string
+	syntax  []*ast.File
+	fset    *token.FileSet
+
+	types  *types.Package
+	parser *parser
+	// mu guards interfaces, which are parsed as they are looked up.
+	mu sync.Mutex
+}
+
+// lookupInterface returns the interface named name in p, parsing it the
+// first time it is looked up, or nil if p declares no such interface.
+func (p *Package) lookupInterface(name string) (*Interface, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, i := range p.interfaces {
+		if i.name == name {
+			return i, nil
+		}
+	}
+	if p.types == nil {
+		return nil, nil
+	}
+
+	i, err := p.parser.parseNamed(p.types, name)
+	if err != nil || i == nil {
+		return nil, err
+	}
+	p.interfaces = append(p.interfaces, i)
+
+	return i, nil
 }
 
 func (g *Generator) Printf(format string, args ...interface{}) {
 	_, _ = fmt.Fprintf(&g.buf, format, args...)
 }
 
-func (g *Generator) ParsePackage(patterns []string) {
-	cfg := &packages.Config{
-		Mode: packages.NeedName |
-			packages.NeedTypesInfo |
-			packages.NeedSyntax |
-			packages.NeedTypes,
-	}
-	pkgs, err := packages.Load(cfg, patterns...)
+func (g *Generator) ParsePackage(patterns []string) error {
+	pkgs, err := packages.Load(g.config(loadMode), patterns...)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	for _, pkg := range pkgs {
-		g.addPackage(pkg)
+	for _, pkg := range withTestVariants(pkgs) {
+		if err := g.addPackage(pkg); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
-func (g *Generator) addPackage(pkg *packages.Package) {
+func (g *Generator) addPackage(pkg *packages.Package) error {
 	log.Printf("adding package \"%s\" (\"%s\")", pkg.Name, pkg.PkgPath)
 	pp := &parser{
 		imports:            make(map[string]ImportedPackage),
@@ -73,7 +181,7 @@ func (g *Generator) addPackage(pkg *packages.Package) {
 	var err error
 	g.pkgs[pkg.PkgPath], err = pp.parsePackage(pkg)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	if g.packageMap == nil {
@@ -83,29 +191,104 @@ func (g *Generator) addPackage(pkg *packages.Package) {
 	for _, i := range g.pkgs[pkg.PkgPath].imports {
 		g.packageMap[i.Path()] = i.Name()
 	}
+
+	return nil
 }
 
-func (g *Generator) GenerateAll(types []string) {
+func (g *Generator) GenerateAll(types []string) error {
 	for _, t := range types {
-		g.Generate(t)
+		if err := g.Generate(t); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
-func (g *Generator) Generate(typeName string) {
+func (g *Generator) Generate(typeName string) error {
 	log.Printf("generating for %s", typeName)
 
-	if _, ok := g.packageMap[openTracingPackagePath]; !ok {
-		g.packageMap[openTracingPackagePath] = openTracingPackageName
+	if err := g.loadInterface(typeName); err != nil {
+		return err
+	}
+	g.generate(typeName)
+
+	return nil
+}
+
+// Reset discards the contents of the Generator's buffer so that it can be
+// used to generate another file.
+func (g *Generator) Reset() {
+	g.buf.Reset()
+}
+
+// loadInterface sets the Generator's Interface to the interface named
+// typeName in the root package.
+func (g *Generator) loadInterface(typeName string) error {
+	pkg, ok := g.pkgs[g.RootPackage]
+	if !ok {
+		return fmt.Errorf("package %s was not loaded", g.RootPackage)
+	}
+	is, err := pkg.lookupInterface(typeName)
+	if err != nil {
+		return err
 	}
+	if is == nil {
+		return fmt.Errorf("%s is not an interface declared in package %s", typeName, g.RootPackage)
+	}
+
+	g.Interface = *is
+	// The methods are sorted in place, and the package may be shared with
+	// other Generators.
+	g.Interface.methods = append([]Method(nil), is.methods...)
 
-	for _, is := range g.pkgs[g.RootPackage].interfaces {
-		if is.name == typeName {
-			g.Interface = *is
-			break
+	return g.validate(typeName)
+}
+
+// validate checks that the Interface can be implemented by a type generated
+// in the output package, and resolves the extractors of the ContextTags.
+func (g *Generator) validate(typeName string) error {
+	if g.Interface.generic {
+		return fmt.Errorf("%s has type parameters; only non-generic interfaces can be wrapped", typeName)
+	}
+	if g.Interface.constraint {
+		return fmt.Errorf("%s can only be used as a type constraint, e.g. because it embeds a union, so it can not be implemented", typeName)
+	}
+
+	outputPackage := g.OutputPackagePath
+	if outputPackage == "" {
+		outputPackage = g.RootPackage
+	}
+
+	for _, m := range g.Interface.methods {
+		if m.reachableFrom(outputPackage) {
+			continue
 		}
+
+		declared := "declared by " + typeName
+		if m.embeddedFrom != "" {
+			declared = "promoted from the embedded interface " + m.embeddedFrom
+		}
+		reason := fmt.Sprintf("%s has unexported method %s, %s, which can only be implemented in package %s", typeName, m.name, declared, m.pkg)
+		if m.pkg == g.RootPackage {
+			return fmt.Errorf("%s; generate the output into a file in that package instead of package %s", reason, outputPackage)
+		}
+		return fmt.Errorf("%s; %s can not be wrapped outside of that package", reason, typeName)
 	}
 
-	g.generate(typeName)
+	if err := g.validateTags(typeName, outputPackage); err != nil {
+		return err
+	}
+	if err := g.validateBaggage(); err != nil {
+		return err
+	}
+	if err := g.validateCarriers(typeName); err != nil {
+		return err
+	}
+
+	var err error
+	g.extractors, err = g.checkContextTags(outputPackage)
+	return err
 }
 
 // Format returns the gofmt-ed contents of the Generator's buffer.
@@ -130,123 +313,325 @@ func (g *Generator) Format() []byte {
 }
 
 func (g *Generator) generate(typeName string) {
-	g.printHeader()
-	g.printImports()
+	g.printHeader(typeName, g.fileConstraint(false))
+	generated := []string{contextPackagePath, runtimePackagePath}
+	if len(g.Interface.tags) > 0 || g.propagates(typeName) {
+		generated = append(generated, openTracingPackagePath)
+	}
+	if g.Interface.hasSpanKind() {
+		generated = append(generated, extPackagePath)
+	}
+	g.printImports(append(generated, g.contextTagImports()...)...)
 	g.printStruct(typeName)
 	g.printMethods(typeName)
 }
 
-func (g *Generator) printHeader() {
-	g.Printf("// Code generated by \"traceable %s\"; DO NOT EDIT.\n", strings.Join(os.Args[1:], " "))
+// printHeader prints the header of a file generated for typeName, which
+// records the signature of the interface so that UpToDate can tell whether
+// the file needs to be generated again, and the file's build constraint, if
+// any.
+func (g *Generator) printHeader(typeName string, build constraint.Expr) {
+	g.Printf("// Code generated by \"traceable %s\"; DO NOT EDIT.\n", strings.Join(g.args(), " "))
+	if pkg, ok := g.pkgs[g.RootPackage]; ok {
+		if sig, ok := g.signature(typeName, pkg.fset, pkg.syntax); ok {
+			g.Printf("%s%s %s\n", directivePrefix, signatureDirective, sig)
+		}
+	}
 	g.Printf("\n")
+	if build != nil {
+		g.Printf("//go:build %s\n", build)
+		if lines, err := constraint.PlusBuildLines(build); err == nil {
+			for _, line := range lines {
+				g.Printf("%s\n", line)
+			}
+		}
+		g.Printf("\n")
+	}
 	g.Printf("package %s", filepath.Base(g.OutputPackagePath))
 	g.Printf("\n")
 }
 
-func (g *Generator) printImports() {
-	if g.OutputPackagePath == "" && len(g.pkgs) == 1 {
-		return
+// args returns the arguments traceable was run with.
+func (g *Generator) args() []string {
+	if g.Args == nil {
+		return os.Args[1:]
 	}
 
+	return g.Args
+}
+
+// printImports prints the imports used by the Interface along with the
+// import paths used by the code generated for it, and decides the names they
+// are referred to by in the generated file.
+func (g *Generator) printImports(generated ...string) {
 	usedImports := g.Interface.imports()
-	usedImports[openTracingPackagePath] = struct{}{}
 	if g.OutputPackagePath != g.RootPackage {
 		usedImports[g.RootPackage] = struct{}{}
 	}
+	delete(usedImports, g.OutputPackagePath)
 
-	g.Printf("import(\n")
-	for importPath := range g.packageMap {
-		if g.OutputPackagePath == importPath {
-			continue
+	// The packages the Interface refers to are named first so that they keep
+	// the names they are written with in the source wherever possible.
+	var importPaths []string
+	for importPath := range usedImports {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+
+	g.imports = importSet{}
+	for _, importPath := range append(importPaths, generated...) {
+		if importPath != g.OutputPackagePath {
+			g.imports.add(importPath, g.preferredName(importPath))
 		}
-		if _, ok := usedImports[importPath]; ok {
-			g.Printf("\"%s\"\n", importPath)
+	}
+
+	if g.OutputPackagePath == "" && len(g.pkgs) == 1 {
+		return
+	}
+
+	importPaths = importPaths[:0]
+	for importPath := range g.imports.names {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+
+	g.Printf("import(\n")
+	for _, importPath := range importPaths {
+		if name := g.imports.names[importPath]; name != g.knownName(importPath) {
+			g.Printf("%s ", name)
 		}
+		g.Printf("\"%s\"\n", importPath)
 	}
 	g.Printf(")\n")
 }
 
+// preferredName returns the name importPath is imported as in the root
+// package, which is the name generated files import it as unless that
+// collides with another import.
+func (g *Generator) preferredName(importPath string) string {
+	if pkg, ok := g.pkgs[g.RootPackage]; ok {
+		if alias, ok := pkg.aliases[importPath]; ok {
+			return alias
+		}
+	}
+
+	return g.knownName(importPath)
+}
+
+// knownName returns the name of the package at importPath.
+func (g *Generator) knownName(importPath string) string {
+	if name, ok := g.packageMap[importPath]; ok {
+		return name
+	}
+	if name, ok := generatedPackages[importPath]; ok {
+		return name
+	}
+
+	return path.Base(importPath)
+}
+
+// importName returns the name the package at importPath is referred to by in
+// the generated file.
+func (g *Generator) importName(importPath string) string {
+	if name, ok := g.imports.names[importPath]; ok {
+		return name
+	}
+
+	return g.knownName(importPath)
+}
+
 func (g *Generator) printStruct(typeName string) {
-	split := strings.Split(typeName, ".")
-	structName := split[len(split)-1]
-	importPath := g.importPath(typeName)
+	structName := getStructName(typeName)
+	interfaceName := g.interfaceName(typeName)
+	rt := g.importName(runtimePackagePath)
 
 	g.Printf("// Traced%s is a traced implementation of %s\n", structName, typeName)
 	g.Printf("type Traced%s struct {\n", structName)
-	g.Printf("\tx ")
+	g.Printf("\tx %s\n", interfaceName)
+	g.Printf("\to *%s.Options\n", rt)
+	g.Printf("\n")
+	g.Printf("// ShouldTrace, if set, is called before each traced method with the\n")
+	g.Printf("// method's context and name. When it returns false the call is passed\n")
+	g.Printf("// straight through to the wrapped value without starting a span.\n")
+	g.Printf("ShouldTrace func(ctx %s.Context, method string) bool\n", g.importName(contextPackagePath))
+	g.Printf("}")
+	g.Printf("\n")
+	g.Printf("\n")
+	g.Printf("// This is a compile-time assertion that the generated code is compatible\n")
+	g.Printf("// with the version of the traceable runtime package it is built with.\n")
+	g.Printf("const _ = %s.SupportPackageIsVersion%d\n", rt, runtimeVersion)
+	g.Printf("\n")
+	g.printTags(typeName)
+	g.Printf("// NewTraced%[1]s returns a Traced%[1]s that wraps x.\n", structName)
+	g.Printf("func NewTraced%[1]s(x %[2]s, opts ...%[3]s.Option) *Traced%[1]s {\n", structName, interfaceName, rt)
+	g.Printf("return &Traced%s{x: x, o: %s.NewOptions(opts...)}\n", structName, rt)
+	g.Printf("}\n")
+	g.Printf("\n")
+}
+
+// interfaceName returns the name of the interface identified by typeName as
+// it should be referred to from the output package.
+func (g *Generator) interfaceName(typeName string) string {
+	split := strings.Split(typeName, ".")
+	structName := split[len(split)-1]
+	importPath := g.importPath(typeName)
 
 	if (g.OutputPackagePath != "" && len(split) == 1) && importPath != g.OutputPackagePath {
-		g.Printf(g.packageMap[importPath] + ".")
+		return g.importName(importPath) + "." + structName
 	}
 
-	g.Printf("%s\n", structName)
-	g.Printf("}")
-	g.Printf("\n")
+	return structName
 }
 
 func (g *Generator) printMethods(typeName string) {
 	structName := getStructName(typeName)
+	rt := g.importName(runtimePackagePath)
 
-	sort.Slice(g.Interface.methods, func(i, j int) bool {
-		return g.Interface.methods[i].name < g.Interface.methods[j].name
-	})
+	g.sortMethods()
 	for i, m := range g.Interface.methods {
-		args := make([]string, len(m.args))
-		argNames := make([]string, len(m.args))
-		argList := make([]string, len(m.args))
-		for i, a := range m.args {
-			argName := "a" + strconv.Itoa(i)
-			argNames[i] = argName
-
-			var b bytes.Buffer
-			if m.isVariadic && i == len(m.returns)-1 {
-				b.WriteString("...")
-				s, ok := a.(*types.Slice)
-				if !ok {
-					log.Fatal("attempting to output variadic argument but was unable to convert the type to a slice")
-				}
-
-				types.WriteType(&b, s.Elem(), g.packageName)
-				argNames[i] += "..."
-			} else {
-				types.WriteType(&b, a, g.packageName)
+		argList, argNames := g.params(m)
+
+		traced := g.traced(structName, m)
+
+		// Traced methods name their results so that they can be inspected
+		// once the call has returned.
+		g.Printf("func (t *Traced%s) %s(%s) %s {\n", structName, m.name, strings.Join(argList, ","), g.results(m, traced))
+		if traced {
+			g.Printf("if t.ShouldTrace != nil && !t.ShouldTrace(%s, \"%s\") {\n", m.contextArg(), m.name)
+			g.printDelegate(m, argNames)
+			if len(m.returns) == 0 {
+				g.Printf("return\n")
 			}
-			args[i] = b.String()
-
-			argList[i] = argName + " " + args[i]
-		}
-
-		returns := make([]string, len(m.returns))
-		for i, r := range m.returns {
-			var b bytes.Buffer
-			types.WriteType(&b, r, g.packageName)
-			returns[i] = b.String()
+			g.Printf("}\n")
+			// The spans of asynchronous methods follow from the caller's
+			// span rather than being its children.
+			start := "StartSpan"
+			if m.async {
+				start = "StartAsyncSpan"
+			}
+			g.Printf("span, %[1]s := %[2]s.%[3]s(%[1]s, t.o, \"%[4]s.%[5]s\"%[6]s)\n", m.contextArg(), rt, start, structName, m.name, g.startSpanOptions(typeName, m))
+			errResult := "nil"
+			if r := m.errorResult(); r != -1 {
+				errResult = "&" + resultName(r)
+			}
+			g.Printf("defer %s.FinishSpanWithContext(%s, span, t.o, \"%s\", %s)\n", rt, m.contextArg(), m.name, errResult)
+			g.printBaggage(m)
+			g.printInject(m)
+			g.printArgTags(m)
+			g.printContextTags(m)
+			g.printSizes(m)
 		}
-		var returnStr string
-		switch len(returns) {
-		case 0:
-		case 1:
-			returnStr = returns[0]
-		default:
-			returnStr = "(" + strings.Join(returns, ",") + ")"
+		g.printDelegate(m, argNames)
+		g.Printf("}\n")
+		if i != len(g.Interface.methods)-1 {
+			g.Printf("\n")
 		}
+	}
+}
+
+// params returns the parameter list of m, with each parameter named after
+// its position, along with the arguments that pass those parameters on to
+// another call.
+func (g *Generator) params(m Method) (params, args []string) {
+	params = make([]string, len(m.args))
+	args = make([]string, len(m.args))
+	for i, a := range m.args {
+		name := "a" + strconv.Itoa(i)
+		args[i] = name
+
+		var b bytes.Buffer
+		if m.isVariadic && i == len(m.args)-1 {
+			s, ok := a.(*types.Slice)
+			if !ok {
+				log.Fatal("attempting to output variadic argument but was unable to convert the type to a slice")
+			}
 
-		g.Printf("func (t *Traced%s) %s(%s) %s {\n", structName, m.name, strings.Join(argList, ","), returnStr)
-		if m.acceptsContext() {
-			g.Printf("span, %[1]s := opentracing.StartSpanFromContext(%[1]s, \"%s.%s\")\n", m.contextArg(), structName, m.name)
-			g.Printf("defer func() {\n")
-			g.Printf("span.Finish()\n")
-			g.Printf("}()\n")
+			b.WriteString("...")
+			a = s.Elem()
+			args[i] += "..."
 		}
-		if len(m.returns) > 0 {
-			g.Printf("return ")
+		types.WriteType(&b, a, g.packageName)
+		params[i] = name + " " + b.String()
+	}
+
+	return params, args
+}
+
+// results returns the result list of m, optionally naming each result after
+// its position.
+func (g *Generator) results(m Method, named bool) string {
+	results := make([]string, len(m.returns))
+	for i, r := range m.returns {
+		var b bytes.Buffer
+		if named {
+			b.WriteString(resultName(i) + " ")
 		}
-		g.Printf("t.x.%s(%s)\n", m.name, strings.Join(argNames, ","))
-		g.Printf("}\n")
-		if i != len(g.Interface.methods)-1 {
-			g.Printf("\n")
+		types.WriteType(&b, r, g.packageName)
+		results[i] = b.String()
+	}
+
+	switch {
+	case len(results) == 0:
+		return ""
+	case len(results) == 1 && !named:
+		return results[0]
+	default:
+		return "(" + strings.Join(results, ",") + ")"
+	}
+}
+
+// typeString returns t as it should be written in the output package.
+func (g *Generator) typeString(t types.Type) string {
+	var b bytes.Buffer
+	types.WriteType(&b, t, g.packageName)
+	return b.String()
+}
+
+// sortMethods sorts the methods of the Interface by name so that they are
+// generated in a stable order.
+func (g *Generator) sortMethods() {
+	sort.Slice(g.Interface.methods, func(i, j int) bool {
+		return g.Interface.methods[i].name < g.Interface.methods[j].name
+	})
+}
+
+// printDelegate prints the call of m on the wrapped value, returning its
+// results if it has any.
+func (g *Generator) printDelegate(m Method, argNames []string) {
+	if len(m.returns) > 0 {
+		g.Printf("return ")
+	}
+	g.Printf("t.x.%s(%s)\n", m.name, strings.Join(argNames, ","))
+}
+
+// tracesAny reports whether at least one method of the Interface is traced.
+func (g *Generator) tracesAny() bool {
+	structName := getStructName(g.Interface.name)
+	for _, m := range g.Interface.methods {
+		if g.traced(structName, m) {
+			return true
 		}
 	}
+
+	return false
+}
+
+// traced reports whether calls to m should be wrapped in a span. Methods
+// that are skipped are generated as plain delegations to the wrapped value.
+func (g *Generator) traced(structName string, m Method) bool {
+	if !m.acceptsContext() || m.skip {
+		return false
+	}
+
+	name := structName + "." + m.name
+	if g.Include != nil && !g.Include.MatchString(name) {
+		return false
+	}
+	if g.Exclude != nil && g.Exclude.MatchString(name) {
+		return false
+	}
+
+	return true
 }
 
 func (g *Generator) importPath(typeName string) string {
@@ -259,6 +644,10 @@ func (g *Generator) importPath(typeName string) string {
 	return g.RootPackage
 }
 
+func resultName(i int) string {
+	return "r" + strconv.Itoa(i)
+}
+
 func getStructName(typeName string) string {
 	idx := strings.IndexRune(typeName, '.')
 	if idx == -1 {
@@ -272,5 +661,8 @@ func (g *Generator) packageName(pkg *types.Package) string {
 	if pkg == nil || g.OutputPackagePath == pkg.Path() {
 		return ""
 	}
+	if name, ok := g.imports.names[pkg.Path()]; ok {
+		return name
+	}
 	return pkg.Name()
 }
@@ -65,10 +65,186 @@ func Test_getStructName(t *testing.T) {
 	}
 }
 
+func Test_Generator_traced(t *testing.T) {
+	withContext := Method{name: "Get", args: []types.Type{newContextType()}}
+
+	tests := []struct {
+		name    string
+		method  Method
+		include string
+		exclude string
+		want    bool
+	}{
+		{
+			name:   "does not accept context",
+			method: Method{name: "Get"},
+			want:   false,
+		},
+		{
+			name:   "accepts context",
+			method: withContext,
+			want:   true,
+		},
+		{
+			name:   "skipped by directive",
+			method: Method{name: "Get", args: withContext.args, skip: true},
+			want:   false,
+		},
+		{
+			name:    "matches include",
+			method:  withContext,
+			include: `^Cache\.`,
+			want:    true,
+		},
+		{
+			name:    "does not match include",
+			method:  withContext,
+			include: `\.Set$`,
+			want:    false,
+		},
+		{
+			name:    "matches exclude",
+			method:  withContext,
+			exclude: `Cache\.Get`,
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g Generator
+			if tt.include != "" {
+				g.Include = regexp.MustCompile(tt.include)
+			}
+			if tt.exclude != "" {
+				g.Exclude = regexp.MustCompile(tt.exclude)
+			}
+
+			qt.Check(t, g.traced("Cache", tt.method), qt.Equals, tt.want)
+		})
+	}
+}
+
+func Test_Generator_params(t *testing.T) {
+	stringSlice := types.NewSlice(types.Typ[types.String])
+
+	tests := []struct {
+		name       string
+		method     Method
+		wantParams []string
+		wantArgs   []string
+	}{
+		{
+			name:       "not variadic",
+			method:     Method{args: []types.Type{newContextType(), stringSlice}},
+			wantParams: []string{"a0 context.Context", "a1 []string"},
+			wantArgs:   []string{"a0", "a1"},
+		},
+		{
+			name: "variadic without results",
+			method: Method{
+				args:       []types.Type{newContextType(), stringSlice},
+				isVariadic: true,
+			},
+			wantParams: []string{"a0 context.Context", "a1 ...string"},
+			wantArgs:   []string{"a0", "a1..."},
+		},
+		{
+			name: "variadic with more results than arguments",
+			method: Method{
+				args:       []types.Type{stringSlice},
+				returns:    []types.Type{types.Typ[types.Int], types.Typ[types.Int], newErrorType()},
+				isVariadic: true,
+			},
+			wantParams: []string{"a0 ...string"},
+			wantArgs:   []string{"a0..."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			params, args := g.params(tt.method)
+			qt.Check(t, params, qt.DeepEquals, tt.wantParams)
+			qt.Check(t, args, qt.DeepEquals, tt.wantArgs)
+		})
+	}
+}
+
+func Test_Generator_validate(t *testing.T) {
+	const (
+		rootPackage   = "github.com/ConorNevin/traceable/internal/tests/unexported"
+		hiddenPackage = "github.com/ConorNevin/traceable/internal/tests/unexported/hidden"
+	)
+
+	g := &Generator{RootPackage: rootPackage}
+	qt.Assert(t, g.ParsePackage([]string{rootPackage}), qt.IsNil)
+
+	tests := []struct {
+		name          string
+		typeName      string
+		outputPackage string
+		inter         *Interface
+		wantErr       string
+	}{
+		{
+			name:          "unexported method in the output package",
+			typeName:      "Sealed",
+			outputPackage: rootPackage,
+		},
+		{
+			name:          "unexported method outside of the output package",
+			typeName:      "Sealed",
+			outputPackage: rootPackage + "/traced",
+			wantErr: "Sealed has unexported method seal, declared by Sealed, which can only be implemented in package " + rootPackage +
+				"; generate the output into a file in that package instead of package " + rootPackage + "/traced",
+		},
+		{
+			name:          "unexported method embedded from another package",
+			typeName:      "External",
+			outputPackage: rootPackage,
+			wantErr: "External has unexported method hide, promoted from the embedded interface " + hiddenPackage + ".Hidden, which can only be implemented in package " + hiddenPackage +
+				"; External can not be wrapped outside of that package",
+		},
+		{
+			name:     "constraint",
+			typeName: "Number",
+			inter:    &Interface{name: "Number", constraint: true},
+			wantErr:  "Number can only be used as a type constraint, e.g. because it embeds a union, so it can not be implemented",
+		},
+		{
+			name:     "generic",
+			typeName: "Store",
+			inter:    &Interface{name: "Store", generic: true},
+			wantErr:  "Store has type parameters; only non-generic interfaces can be wrapped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.OutputPackagePath = tt.outputPackage
+			if tt.inter != nil {
+				g.Interface = *tt.inter
+			} else {
+				i, err := g.pkgs[rootPackage].lookupInterface(tt.typeName)
+				qt.Assert(t, err, qt.IsNil)
+				g.Interface = *i
+			}
+
+			err := g.validate(tt.typeName)
+			if tt.wantErr == "" {
+				qt.Check(t, err, qt.IsNil)
+				return
+			}
+			qt.Check(t, err, qt.ErrorMatches, regexp.QuoteMeta(tt.wantErr))
+		})
+	}
+}
+
 func TestGenerator_generate(t *testing.T) {
 	pm := map[string]string{
-		"context":                               "context",
-		"github.com/opentracing/opentracing-go": "opentracing",
+		"context": "context",
+		"github.com/ConorNevin/traceable/runtime": "runtime",
 	}
 
 	tests := []struct {
@@ -94,11 +270,12 @@ func TestGenerator_generate(t *testing.T) {
 				},
 			},
 			expectedFunctions: map[string]string{
-				"Foo": "func (t *TracedFooBar) Foo(a0 context.Context) builtin.error {",
+				"Foo": "func (t *TracedFooBar) Foo(a0 context.Context) (r0 builtin.error) {",
 			},
 			expectedImports: []string{
+				"builtin",
 				"context",
-				"github.com/opentracing/opentracing-go",
+				"github.com/ConorNevin/traceable/runtime",
 			},
 		},
 	}
@@ -128,6 +305,58 @@ func TestGenerator_generate(t *testing.T) {
 	}
 }
 
+func TestGenerator_generate_shouldTrace(t *testing.T) {
+	g := &Generator{
+		packageMap: map[string]string{
+			"context": "context",
+			"github.com/ConorNevin/traceable/runtime": "runtime",
+		},
+		Interface: Interface{
+			name: "Cache",
+			methods: []Method{
+				{name: "Get", args: []types.Type{newContextType()}, skip: true},
+				{name: "Set", args: []types.Type{newContextType()}},
+			},
+		},
+	}
+	g.generate(g.Interface.name)
+
+	lines := strings.Split(g.buf.String(), "\n")
+
+	idx := findMethodLines(t, "Set", lines)
+	qt.Check(t, lines[idx+1], qt.Equals, `if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Set") {`)
+	qt.Check(t, lines[idx+2], qt.Equals, "t.x.Set(a0)")
+	qt.Check(t, lines[idx+3], qt.Equals, "return")
+
+	idx = findMethodLines(t, "Get", lines)
+	qt.Check(t, lines[idx+1], qt.Equals, "t.x.Get(a0)")
+}
+
+func TestGenerator_generate_async(t *testing.T) {
+	g := &Generator{
+		packageMap: map[string]string{
+			"context": "context",
+			"github.com/ConorNevin/traceable/runtime": "runtime",
+		},
+		Interface: Interface{
+			name: "Jobs",
+			methods: []Method{
+				{name: "Enqueue", args: []types.Type{newContextType()}, async: true},
+				{name: "Status", args: []types.Type{newContextType()}},
+			},
+		},
+	}
+	g.generate(g.Interface.name)
+
+	lines := strings.Split(g.buf.String(), "\n")
+
+	idx := findMethodLines(t, "Enqueue", lines)
+	qt.Check(t, lines[idx+5], qt.Equals, `span, a0 := runtime.StartAsyncSpan(a0, t.o, "Jobs.Enqueue")`)
+
+	idx = findMethodLines(t, "Status", lines)
+	qt.Check(t, lines[idx+5], qt.Equals, `span, a0 := runtime.StartSpan(a0, t.o, "Jobs.Status")`)
+}
+
 func findMethodLines(t *testing.T, methodName string, lines []string) int {
 	t.Helper()
 	r := regexp.MustCompile(fmt.Sprintf(`func\s+\(.*\)\s*%s`, methodName))
@@ -157,7 +386,7 @@ func findImports(t *testing.T, lines []string) []string {
 			return imports
 		default:
 			if foundImportBlock {
-				imports = append(imports, line[1:(len(line)-1)])
+				imports = append(imports, line[strings.IndexByte(line, '"')+1:len(line)-1])
 			}
 		}
 	}
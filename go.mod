@@ -9,4 +9,5 @@ require (
 	github.com/opentracing/opentracing-go v1.2.0
 	golang.org/x/mod v0.7.0
 	golang.org/x/tools v0.3.0
+	gopkg.in/yaml.v3 v3.0.1
 )
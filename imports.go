@@ -2,6 +2,9 @@ package traceable
 
 import (
 	"fmt"
+	"go/ast"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
@@ -15,10 +18,7 @@ type ImportedPackage struct {
 
 func createPackageMap(importPaths []string) (map[string]string, error) {
 	cfg := &packages.Config{
-		Mode: packages.NeedName |
-			packages.NeedTypesInfo |
-			packages.NeedSyntax |
-			packages.NeedTypes,
+		Mode: packages.NeedName,
 	}
 	pkgs, err := packages.Load(cfg, importPaths...)
 	if err != nil {
@@ -45,3 +45,75 @@ func createPackageMap(importPaths []string) (map[string]string, error) {
 
 	return pkgMap, nil
 }
+
+// reservedNames are identifiers declared by generated code, in scope where
+// imported packages are referred to, that an import must not be named after.
+var reservedNames = map[string]bool{
+	"ctx": true, "f": true, "fn": true, "got": true, "ok": true, "opts": true, "r": true,
+	"returns": true, "span": true, "spans": true, "t": true, "tag": true, "tests": true,
+	"tracer": true, "tt": true, "v": true, "want": true, "x": true,
+}
+
+// positionalName matches the names given to parameters and results.
+var positionalName = regexp.MustCompile(`^[ar][0-9]+$`)
+
+// importSet assigns the names that packages are imported as in a generated
+// file, so that no two imports share a name and no import is shadowed by an
+// identifier the generated code declares.
+type importSet struct {
+	names map[string]string // import path -> name
+	paths map[string]string // name -> import path
+}
+
+// add imports importPath, preferably as name, and returns the name it is
+// imported as. Adding a path again returns the name it was first given.
+func (s *importSet) add(importPath, name string) string {
+	if n, ok := s.names[importPath]; ok {
+		return n
+	}
+	if s.names == nil {
+		s.names = make(map[string]string)
+		s.paths = make(map[string]string)
+	}
+
+	unique := name
+	for i := 2; s.taken(unique); i++ {
+		sep := ""
+		if last := name[len(name)-1]; last >= '0' && last <= '9' {
+			sep = "_"
+		}
+		unique = name + sep + strconv.Itoa(i)
+	}
+
+	s.names[importPath] = unique
+	s.paths[unique] = importPath
+	return unique
+}
+
+func (s *importSet) taken(name string) bool {
+	_, ok := s.paths[name]
+	return ok || reservedNames[name] || positionalName.MatchString(name)
+}
+
+// importAliases returns the names that files import packages under when they
+// differ from the package's own name, keyed by import path. Where files
+// disagree, the first alias wins.
+func importAliases(files []*ast.File) map[string]string {
+	aliases := make(map[string]string)
+	for _, f := range files {
+		for _, spec := range f.Imports {
+			if spec.Name == nil || spec.Name.Name == "_" || spec.Name.Name == "." {
+				continue
+			}
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if _, ok := aliases[importPath]; !ok {
+				aliases[importPath] = spec.Name.Name
+			}
+		}
+	}
+
+	return aliases
+}
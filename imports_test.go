@@ -1,6 +1,9 @@
 package traceable
 
 import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -31,3 +34,42 @@ func Test_createPackageMap(t *testing.T) {
 		})
 	}
 }
+
+func Test_importSet_add(t *testing.T) {
+	var s importSet
+	tests := []struct {
+		importPath string
+		name       string
+		want       string
+	}{
+		{"example.com/api/v1", "v1", "v1"},
+		{"example.com/internal/v1", "v1", "v1_2"},
+		{"example.com/api/v1", "apiv1", "v1"},
+		{"example.com/span", "span", "span2"},
+		{"example.com/a0", "a0", "a0_2"},
+		{"example.com/runtime", "runtime", "runtime"},
+		{"github.com/ConorNevin/traceable/runtime", "runtime", "runtime2"},
+	}
+	for _, tt := range tests {
+		qt.Check(t, s.add(tt.importPath, tt.name), qt.Equals, tt.want, qt.Commentf("%s", tt.importPath))
+	}
+}
+
+func Test_importAliases(t *testing.T) {
+	f, err := goparser.ParseFile(token.NewFileSet(), "x.go", `package x
+
+import (
+	_ "embed"
+	. "strings"
+	apiv1 "example.com/api/v1"
+	"example.com/internal/v1"
+	rt "runtime"
+)
+`, goparser.ImportsOnly)
+	qt.Assert(t, err, qt.IsNil)
+
+	qt.Check(t, importAliases([]*ast.File{f}), qt.DeepEquals, map[string]string{
+		"example.com/api/v1": "apiv1",
+		"runtime":            "rt",
+	})
+}
@@ -1,8 +1,21 @@
 package traceable
 
+import "go/build/constraint"
+
 type Interface struct {
 	name    string
 	methods []Method
+
+	// constraint is set when the interface can only be used as a type
+	// constraint, e.g. because it embeds a union, so it can not be wrapped.
+	constraint bool
+	// generic is set when the interface has type parameters.
+	generic bool
+	// build is the build constraint of the file declaring the interface.
+	build constraint.Expr
+	// tags are set on the spans of every traced method, as annotated on
+	// the interface declaration.
+	tags []spanTag
 }
 
 func (i *Interface) hasMethod(m Method) bool {
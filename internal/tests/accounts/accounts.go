@@ -0,0 +1,44 @@
+package accounts
+
+import (
+	"context"
+	"strings"
+)
+
+//go:generate ../../../bin/traceable gen
+
+type Accounts interface {
+	//traceable:tag account.id=id, user.id=user.ID, region=user.Profile.Region
+	Update(ctx context.Context, id string, user *User) error
+	//traceable:tag user
+	Login(ctx context.Context,
+		user Username,
+		pin string, //traceable:sensitive
+	) error
+	//traceable:tag user, attempts=n
+	Lock(ctx context.Context, user Username, n int) error
+}
+
+type User struct {
+	ID    string
+	Email string
+	// SSN is never recorded on spans.
+	//traceable:sensitive
+	SSN     string
+	Profile *Profile
+}
+
+type Profile struct {
+	Region string
+}
+
+// Username is recorded on spans with all but its first letter masked.
+type Username string
+
+func (u Username) Redact() string {
+	if u == "" {
+		return ""
+	}
+
+	return string(u[:1]) + strings.Repeat("*", len(u)-1)
+}
@@ -0,0 +1,67 @@
+// Code generated by "traceable -types Accounts -output accounts_traced.go -emit-tests -deny-names email"; DO NOT EDIT.
+//traceable:signature 879e10311fd5461d763ab0f220d4b1d0
+
+package accounts
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedAccounts is a traced implementation of Accounts
+type TracedAccounts struct {
+	x Accounts
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedAccounts returns a TracedAccounts that wraps x.
+func NewTracedAccounts(x Accounts, opts ...runtime.Option) *TracedAccounts {
+	return &TracedAccounts{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedAccounts) Lock(a0 context.Context, a1 Username, a2 int) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Lock") {
+		return t.x.Lock(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Accounts.Lock")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Lock", &r0)
+	runtime.Tag(span, "user", a1)
+	runtime.Tag(span, "attempts", a2)
+	return t.x.Lock(a0, a1, a2)
+}
+
+func (t *TracedAccounts) Login(a0 context.Context, a1 Username, a2 string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Login") {
+		return t.x.Login(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Accounts.Login")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Login", &r0)
+	runtime.Tag(span, "user", a1)
+	return t.x.Login(a0, a1, a2)
+}
+
+func (t *TracedAccounts) Update(a0 context.Context, a1 string, a2 *User) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Update") {
+		return t.x.Update(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Accounts.Update")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Update", &r0)
+	runtime.Tag(span, "account.id", a1)
+	if a2 != nil {
+		runtime.Tag(span, "user.id", a2.ID)
+	}
+	if a2 != nil && a2.Profile != nil {
+		runtime.Tag(span, "region", a2.Profile.Region)
+	}
+	return t.x.Update(a0, a1, a2)
+}
@@ -0,0 +1,98 @@
+// Code generated by "traceable -types Accounts -output accounts_traced.go -emit-tests -deny-names email"; DO NOT EDIT.
+//traceable:signature 879e10311fd5461d763ab0f220d4b1d0
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingAccounts is a Accounts that records the calls made to it.
+type recordingAccounts struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Accounts = (*recordingAccounts)(nil)
+
+func (r *recordingAccounts) Lock(a0 context.Context, a1 Username, a2 int) (r0 error) {
+	r.calls = append(r.calls, "Lock")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingAccounts) Login(a0 context.Context, a1 Username, a2 string) (r0 error) {
+	r.calls = append(r.calls, "Login")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingAccounts) Update(a0 context.Context, a1 string, a2 *User) (r0 error) {
+	r.calls = append(r.calls, "Update")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedAccounts(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedAccounts)
+	}{
+		{
+			method: "Lock",
+			traced: true,
+			call: func(ctx context.Context, x *TracedAccounts) {
+				x.Lock(ctx, *new(Username), *new(int))
+			},
+		},
+		{
+			method: "Login",
+			traced: true,
+			call: func(ctx context.Context, x *TracedAccounts) {
+				x.Login(ctx, *new(Username), *new(string))
+			},
+		},
+		{
+			method: "Update",
+			traced: true,
+			call: func(ctx context.Context, x *TracedAccounts) {
+				x.Update(ctx, *new(string), *new(*User))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingAccounts{}
+			tt.call(context.Background(), NewTracedAccounts(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Accounts." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
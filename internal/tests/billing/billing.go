@@ -0,0 +1,17 @@
+package billing
+
+import "context"
+
+//go:generate ../../../bin/traceable -types Client -output client_traced.go -emit-tests
+
+// Client calls the billing API.
+//
+//traceable:kind client
+//traceable:component net/http
+//traceable:peer.service billing-api
+type Client interface {
+	Charge(ctx context.Context, account string, cents int64) (string, error)
+	Refund(ctx context.Context, charge string) error
+	//traceable:skip
+	Ping(ctx context.Context) error
+}
@@ -0,0 +1,61 @@
+// Code generated by "traceable -types Client -output client_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 8afcf2ecf2e0b028060d37c1248c1377
+
+package billing
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// TracedClient is a traced implementation of Client
+type TracedClient struct {
+	x Client
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// tracedClientTags are set on every span started by TracedClient.
+var tracedClientTags = opentracing.Tags{
+	"component":    "net/http",
+	"peer.service": "billing-api",
+	"span.kind":    ext.SpanKindEnum("client"),
+}
+
+// NewTracedClient returns a TracedClient that wraps x.
+func NewTracedClient(x Client, opts ...runtime.Option) *TracedClient {
+	return &TracedClient{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedClient) Charge(a0 context.Context, a1 string, a2 int64) (r0 string, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Charge") {
+		return t.x.Charge(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Client.Charge", tracedClientTags)
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Charge", &r1)
+	return t.x.Charge(a0, a1, a2)
+}
+
+func (t *TracedClient) Ping(a0 context.Context) error {
+	return t.x.Ping(a0)
+}
+
+func (t *TracedClient) Refund(a0 context.Context, a1 string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Refund") {
+		return t.x.Refund(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Client.Refund", tracedClientTags)
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Refund", &r0)
+	return t.x.Refund(a0, a1)
+}
@@ -0,0 +1,103 @@
+// Code generated by "traceable -types Client -output client_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 8afcf2ecf2e0b028060d37c1248c1377
+
+package billing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingClient is a Client that records the calls made to it.
+type recordingClient struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Client = (*recordingClient)(nil)
+
+func (r *recordingClient) Charge(a0 context.Context, a1 string, a2 int64) (r0 string, r1 error) {
+	r.calls = append(r.calls, "Charge")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingClient) Ping(a0 context.Context) (r0 error) {
+	r.calls = append(r.calls, "Ping")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingClient) Refund(a0 context.Context, a1 string) (r0 error) {
+	r.calls = append(r.calls, "Refund")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedClient(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedClient)
+	}{
+		{
+			method: "Charge",
+			traced: true,
+			call: func(ctx context.Context, x *TracedClient) {
+				x.Charge(ctx, *new(string), *new(int64))
+			},
+		},
+		{
+			method: "Ping",
+			traced: false,
+			call: func(ctx context.Context, x *TracedClient) {
+				x.Ping(ctx)
+			},
+		},
+		{
+			method: "Refund",
+			traced: true,
+			call: func(ctx context.Context, x *TracedClient) {
+				x.Refund(ctx, *new(string))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingClient{}
+			tt.call(context.Background(), NewTracedClient(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Client." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+			for tag, want := range tracedClientTags {
+				if got := spans[0].Tag(tag); got != want {
+					t.Errorf("expected tag %s to be %v, got %v", tag, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package billing
+
+import "context"
+
+//go:generate ../../../bin/traceable -types Ledger -output ledger_traced.go -emit-tests
+
+// Ledger records the charges made through the billing API. Its spans are
+// tagged with the component and peer service, but not a span kind.
+//
+//traceable:component database/sql
+//traceable:peer.service ledger-db
+type Ledger interface {
+	Record(ctx context.Context, charge string, cents int64) error
+}
@@ -0,0 +1,46 @@
+// Code generated by "traceable -types Ledger -output ledger_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 153469d2874fef7a1e46a497ea08c7c0
+
+package billing
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+)
+
+// TracedLedger is a traced implementation of Ledger
+type TracedLedger struct {
+	x Ledger
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// tracedLedgerTags are set on every span started by TracedLedger.
+var tracedLedgerTags = opentracing.Tags{
+	"component":    "database/sql",
+	"peer.service": "ledger-db",
+}
+
+// NewTracedLedger returns a TracedLedger that wraps x.
+func NewTracedLedger(x Ledger, opts ...runtime.Option) *TracedLedger {
+	return &TracedLedger{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedLedger) Record(a0 context.Context, a1 string, a2 int64) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Record") {
+		return t.x.Record(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Ledger.Record", tracedLedgerTags)
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Record", &r0)
+	return t.x.Record(a0, a1, a2)
+}
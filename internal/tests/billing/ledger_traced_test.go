@@ -0,0 +1,77 @@
+// Code generated by "traceable -types Ledger -output ledger_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 153469d2874fef7a1e46a497ea08c7c0
+
+package billing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingLedger is a Ledger that records the calls made to it.
+type recordingLedger struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Ledger = (*recordingLedger)(nil)
+
+func (r *recordingLedger) Record(a0 context.Context, a1 string, a2 int64) (r0 error) {
+	r.calls = append(r.calls, "Record")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedLedger(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedLedger)
+	}{
+		{
+			method: "Record",
+			traced: true,
+			call: func(ctx context.Context, x *TracedLedger) {
+				x.Record(ctx, *new(string), *new(int64))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingLedger{}
+			tt.call(context.Background(), NewTracedLedger(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Ledger." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+			for tag, want := range tracedLedgerTags {
+				if got := spans[0].Tag(tag); got != want {
+					t.Errorf("expected tag %s to be %v, got %v", tag, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+package buildtags
+
+//go:generate ../../../bin/traceable -types Fixtures -tags integration -output fixtures_traced.go -emit-tests
+//go:generate ../../../bin/traceable -types Service -goos windows -output service_traced.go -emit-tests
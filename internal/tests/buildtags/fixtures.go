@@ -0,0 +1,12 @@
+//go:build integration
+// +build integration
+
+package buildtags
+
+import "context"
+
+// Fixtures sets up the data used by the integration tests.
+type Fixtures interface {
+	Load(ctx context.Context, names ...string) error
+	Reset(ctx context.Context) error
+}
@@ -0,0 +1,51 @@
+// Code generated by "traceable -types Fixtures -tags integration -output fixtures_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature dfa9ca435ad8c67ca071833511be09c9
+
+//go:build integration
+// +build integration
+
+package buildtags
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedFixtures is a traced implementation of Fixtures
+type TracedFixtures struct {
+	x Fixtures
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedFixtures returns a TracedFixtures that wraps x.
+func NewTracedFixtures(x Fixtures, opts ...runtime.Option) *TracedFixtures {
+	return &TracedFixtures{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedFixtures) Load(a0 context.Context, a1 ...string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Load") {
+		return t.x.Load(a0, a1...)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Fixtures.Load")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Load", &r0)
+	return t.x.Load(a0, a1...)
+}
+
+func (t *TracedFixtures) Reset(a0 context.Context) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Reset") {
+		return t.x.Reset(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Fixtures.Reset")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Reset", &r0)
+	return t.x.Reset(a0)
+}
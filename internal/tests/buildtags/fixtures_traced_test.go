@@ -0,0 +1,88 @@
+// Code generated by "traceable -types Fixtures -tags integration -output fixtures_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature dfa9ca435ad8c67ca071833511be09c9
+
+//go:build integration
+// +build integration
+
+package buildtags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingFixtures is a Fixtures that records the calls made to it.
+type recordingFixtures struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Fixtures = (*recordingFixtures)(nil)
+
+func (r *recordingFixtures) Load(a0 context.Context, a1 ...string) (r0 error) {
+	r.calls = append(r.calls, "Load")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingFixtures) Reset(a0 context.Context) (r0 error) {
+	r.calls = append(r.calls, "Reset")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedFixtures(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedFixtures)
+	}{
+		{
+			method: "Load",
+			traced: true,
+			call: func(ctx context.Context, x *TracedFixtures) {
+				x.Load(ctx)
+			},
+		},
+		{
+			method: "Reset",
+			traced: true,
+			call: func(ctx context.Context, x *TracedFixtures) {
+				x.Reset(ctx)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingFixtures{}
+			tt.call(context.Background(), NewTracedFixtures(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Fixtures." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by "traceable -types Service -goos windows -output service_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 4d9aed34d9370a1cd1a07f7c81d3e42e
+
+//go:build windows
+// +build windows
+
+package buildtags
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedService is a traced implementation of Service
+type TracedService struct {
+	x Service
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedService returns a TracedService that wraps x.
+func NewTracedService(x Service, opts ...runtime.Option) *TracedService {
+	return &TracedService{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedService) Start(a0 context.Context, a1 ...string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Start") {
+		return t.x.Start(a0, a1...)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Service.Start")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Start", &r0)
+	return t.x.Start(a0, a1...)
+}
+
+func (t *TracedService) Stop(a0 context.Context) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Stop") {
+		return t.x.Stop(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Service.Stop")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Stop", &r0)
+	return t.x.Stop(a0)
+}
@@ -0,0 +1,88 @@
+// Code generated by "traceable -types Service -goos windows -output service_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 4d9aed34d9370a1cd1a07f7c81d3e42e
+
+//go:build windows
+// +build windows
+
+package buildtags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingService is a Service that records the calls made to it.
+type recordingService struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Service = (*recordingService)(nil)
+
+func (r *recordingService) Start(a0 context.Context, a1 ...string) (r0 error) {
+	r.calls = append(r.calls, "Start")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingService) Stop(a0 context.Context) (r0 error) {
+	r.calls = append(r.calls, "Stop")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedService(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedService)
+	}{
+		{
+			method: "Start",
+			traced: true,
+			call: func(ctx context.Context, x *TracedService) {
+				x.Start(ctx)
+			},
+		},
+		{
+			method: "Stop",
+			traced: true,
+			call: func(ctx context.Context, x *TracedService) {
+				x.Stop(ctx)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingService{}
+			tt.call(context.Background(), NewTracedService(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Service." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
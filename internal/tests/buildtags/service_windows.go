@@ -0,0 +1,10 @@
+package buildtags
+
+import "context"
+
+// Service controls a Windows service. It is declared in a file that is only
+// built on Windows because of its name, without a build constraint.
+type Service interface {
+	Start(ctx context.Context, args ...string) error
+	Stop(ctx context.Context) error
+}
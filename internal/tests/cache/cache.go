@@ -0,0 +1,15 @@
+package cache
+
+import (
+	"context"
+)
+
+//go:generate ../../../bin/traceable -types Cache -output cache_traced.go
+//go:generate ../../../bin/traceable -types Cache -fake -output fake_cache.go
+
+type Cache interface {
+	// Get is called on every request, so it is not worth the cost of a span.
+	//traceable:skip
+	Get(context.Context, string) ([]byte, bool)
+	Set(context.Context, string, []byte) error
+}
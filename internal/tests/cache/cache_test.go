@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTracedCache_FakeCache(t *testing.T) {
+	fake := &FakeCache{}
+	fake.GetReturns([]byte("bar"), true)
+	fake.SetFunc = func(_ context.Context, key string, _ []byte) error {
+		if key == "" {
+			return errors.New("empty key")
+		}
+		return nil
+	}
+
+	c := NewTracedCache(fake)
+	ctx := context.Background()
+
+	if v, ok := c.Get(ctx, "foo"); !ok || string(v) != "bar" {
+		t.Errorf("expected Get to return the configured values, got %q, %t", v, ok)
+	}
+	if err := c.Set(ctx, "", nil); err == nil {
+		t.Error("expected Set to call SetFunc")
+	}
+
+	fake.AssertGetCallCount(t, 1)
+	fake.AssertSetCallCount(t, 1)
+	if calls := fake.GetCalls(); calls[0].A1 != "foo" {
+		t.Errorf("expected Get to be called with %q, got %q", "foo", calls[0].A1)
+	}
+}
@@ -0,0 +1,43 @@
+// Code generated by "traceable -types Cache -output cache_traced.go"; DO NOT EDIT.
+//traceable:signature 3a9b46b5cf9053fac57e2e07f07010b0
+
+package cache
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedCache is a traced implementation of Cache
+type TracedCache struct {
+	x Cache
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedCache returns a TracedCache that wraps x.
+func NewTracedCache(x Cache, opts ...runtime.Option) *TracedCache {
+	return &TracedCache{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedCache) Get(a0 context.Context, a1 string) ([]byte, bool) {
+	return t.x.Get(a0, a1)
+}
+
+func (t *TracedCache) Set(a0 context.Context, a1 string, a2 []byte) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Set") {
+		return t.x.Set(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Cache.Set")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Set", &r0)
+	return t.x.Set(a0, a1, a2)
+}
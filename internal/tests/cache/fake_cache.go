@@ -0,0 +1,136 @@
+// Code generated by "traceable -types Cache -fake -output fake_cache.go"; DO NOT EDIT.
+//traceable:signature 0f6a7b545bc2cc4d33144b8eeffb71ae
+
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeCache is a fake implementation of Cache that records the calls made to it.
+type FakeCache struct {
+	mu sync.Mutex
+
+	// GetFunc, if set, is called by Get.
+	GetFunc    func(context.Context, string) ([]byte, bool)
+	callsGet   []FakeCacheGetCall
+	returnsGet struct {
+		r0 []byte
+		r1 bool
+	}
+
+	// SetFunc, if set, is called by Set.
+	SetFunc    func(context.Context, string, []byte) error
+	callsSet   []FakeCacheSetCall
+	returnsSet struct {
+		r0 error
+	}
+}
+
+var _ Cache = (*FakeCache)(nil)
+
+// FakeCacheGetCall holds the arguments of a call to FakeCache.Get.
+type FakeCacheGetCall struct {
+	A0 context.Context
+	A1 string
+}
+
+// FakeCacheSetCall holds the arguments of a call to FakeCache.Set.
+type FakeCacheSetCall struct {
+	A0 context.Context
+	A1 string
+	A2 []byte
+}
+
+// Get implements Cache.
+func (f *FakeCache) Get(a0 context.Context, a1 string) ([]byte, bool) {
+	f.mu.Lock()
+	f.callsGet = append(f.callsGet, FakeCacheGetCall{A0: a0, A1: a1})
+	fn := f.GetFunc
+	returns := f.returnsGet
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1)
+	}
+	return returns.r0, returns.r1
+}
+
+// GetReturns sets the values returned by Get when GetFunc is not set.
+func (f *FakeCache) GetReturns(r0 []byte, r1 bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsGet.r0, f.returnsGet.r1 = r0, r1
+}
+
+// GetCalls returns the arguments of each call made to Get.
+func (f *FakeCache) GetCalls() []FakeCacheGetCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeCacheGetCall(nil), f.callsGet...)
+}
+
+// GetCallCount returns the number of calls made to Get.
+func (f *FakeCache) GetCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsGet)
+}
+
+// AssertGetCallCount fails the test unless Get was called want times.
+func (f *FakeCache) AssertGetCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.GetCallCount(); got != want {
+		t.Errorf("expected FakeCache.Get to be called %d times, got %d", want, got)
+	}
+}
+
+// Set implements Cache.
+func (f *FakeCache) Set(a0 context.Context, a1 string, a2 []byte) error {
+	f.mu.Lock()
+	f.callsSet = append(f.callsSet, FakeCacheSetCall{A0: a0, A1: a1, A2: a2})
+	fn := f.SetFunc
+	returns := f.returnsSet
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1, a2)
+	}
+	return returns.r0
+}
+
+// SetReturns sets the values returned by Set when SetFunc is not set.
+func (f *FakeCache) SetReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsSet.r0 = r0
+}
+
+// SetCalls returns the arguments of each call made to Set.
+func (f *FakeCache) SetCalls() []FakeCacheSetCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeCacheSetCall(nil), f.callsSet...)
+}
+
+// SetCallCount returns the number of calls made to Set.
+func (f *FakeCache) SetCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsSet)
+}
+
+// AssertSetCallCount fails the test unless Set was called want times.
+func (f *FakeCache) AssertSetCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.SetCallCount(); got != want {
+		t.Errorf("expected FakeCache.Set to be called %d times, got %d", want, got)
+	}
+}
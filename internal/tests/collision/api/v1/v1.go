@@ -0,0 +1,7 @@
+package v1
+
+// Request is a request to store a record.
+type Request struct {
+	Key   string
+	Value []byte
+}
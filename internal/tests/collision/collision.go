@@ -0,0 +1,20 @@
+package collision
+
+//go:generate ../../../bin/traceable -types Store -output traced/store.go -emit-tests
+
+import (
+	"context"
+
+	apiv1 "github.com/ConorNevin/traceable/internal/tests/collision/api/v1"
+	"github.com/ConorNevin/traceable/internal/tests/collision/internal/v1"
+	"github.com/ConorNevin/traceable/internal/tests/collision/runtime"
+	"github.com/ConorNevin/traceable/internal/tests/collision/span"
+)
+
+// Store refers to packages whose names collide with each other and with the
+// names used by generated code.
+type Store interface {
+	Put(ctx context.Context, req apiv1.Request) (v1.Record, error)
+	Annotate(ctx context.Context, s span.Span) error
+	Config(ctx context.Context) (*runtime.Config, error)
+}
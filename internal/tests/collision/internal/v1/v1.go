@@ -0,0 +1,8 @@
+package v1
+
+// Record is a stored record.
+type Record struct {
+	Key     string
+	Value   []byte
+	Version int
+}
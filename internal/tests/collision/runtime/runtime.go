@@ -0,0 +1,6 @@
+package runtime
+
+// Config is the configuration of a store.
+type Config struct {
+	Replicas int
+}
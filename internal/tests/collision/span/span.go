@@ -0,0 +1,6 @@
+package span
+
+// Span is an annotation attached to a record.
+type Span struct {
+	Start, End int
+}
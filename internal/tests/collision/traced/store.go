@@ -0,0 +1,62 @@
+// Code generated by "traceable -types Store -output traced/store.go -emit-tests"; DO NOT EDIT.
+//traceable:signature f08e98fc062825bca6798d9c87e64cbb
+
+package traced
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/internal/tests/collision"
+	apiv1 "github.com/ConorNevin/traceable/internal/tests/collision/api/v1"
+	"github.com/ConorNevin/traceable/internal/tests/collision/internal/v1"
+	"github.com/ConorNevin/traceable/internal/tests/collision/runtime"
+	span2 "github.com/ConorNevin/traceable/internal/tests/collision/span"
+	runtime2 "github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedStore is a traced implementation of Store
+type TracedStore struct {
+	x collision.Store
+	o *runtime2.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime2.SupportPackageIsVersion7
+
+// NewTracedStore returns a TracedStore that wraps x.
+func NewTracedStore(x collision.Store, opts ...runtime2.Option) *TracedStore {
+	return &TracedStore{x: x, o: runtime2.NewOptions(opts...)}
+}
+
+func (t *TracedStore) Annotate(a0 context.Context, a1 span2.Span) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Annotate") {
+		return t.x.Annotate(a0, a1)
+	}
+	span, a0 := runtime2.StartSpan(a0, t.o, "Store.Annotate")
+	defer runtime2.FinishSpanWithContext(a0, span, t.o, "Annotate", &r0)
+	return t.x.Annotate(a0, a1)
+}
+
+func (t *TracedStore) Config(a0 context.Context) (r0 *runtime.Config, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Config") {
+		return t.x.Config(a0)
+	}
+	span, a0 := runtime2.StartSpan(a0, t.o, "Store.Config")
+	defer runtime2.FinishSpanWithContext(a0, span, t.o, "Config", &r1)
+	return t.x.Config(a0)
+}
+
+func (t *TracedStore) Put(a0 context.Context, a1 apiv1.Request) (r0 v1.Record, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Put") {
+		return t.x.Put(a0, a1)
+	}
+	span, a0 := runtime2.StartSpan(a0, t.o, "Store.Put")
+	defer runtime2.FinishSpanWithContext(a0, span, t.o, "Put", &r1)
+	return t.x.Put(a0, a1)
+}
@@ -0,0 +1,103 @@
+// Code generated by "traceable -types Store -output traced/store.go -emit-tests"; DO NOT EDIT.
+//traceable:signature f08e98fc062825bca6798d9c87e64cbb
+
+package traced
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/internal/tests/collision"
+	apiv1 "github.com/ConorNevin/traceable/internal/tests/collision/api/v1"
+	"github.com/ConorNevin/traceable/internal/tests/collision/internal/v1"
+	"github.com/ConorNevin/traceable/internal/tests/collision/runtime"
+	span2 "github.com/ConorNevin/traceable/internal/tests/collision/span"
+	runtime2 "github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingStore is a collision.Store that records the calls made to it.
+type recordingStore struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ collision.Store = (*recordingStore)(nil)
+
+func (r *recordingStore) Annotate(a0 context.Context, a1 span2.Span) (r0 error) {
+	r.calls = append(r.calls, "Annotate")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingStore) Config(a0 context.Context) (r0 *runtime.Config, r1 error) {
+	r.calls = append(r.calls, "Config")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingStore) Put(a0 context.Context, a1 apiv1.Request) (r0 v1.Record, r1 error) {
+	r.calls = append(r.calls, "Put")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedStore(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedStore)
+	}{
+		{
+			method: "Annotate",
+			traced: true,
+			call: func(ctx context.Context, x *TracedStore) {
+				x.Annotate(ctx, *new(span2.Span))
+			},
+		},
+		{
+			method: "Config",
+			traced: true,
+			call: func(ctx context.Context, x *TracedStore) {
+				x.Config(ctx)
+			},
+		},
+		{
+			method: "Put",
+			traced: true,
+			call: func(ctx context.Context, x *TracedStore) {
+				x.Put(ctx, *new(apiv1.Request))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingStore{}
+			tt.call(context.Background(), NewTracedStore(x, runtime2.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Store." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
@@ -1,4 +1,5 @@
 // Code generated by "traceable -types AnotherEmbedded -output another_embedded_types_traced.go"; DO NOT EDIT.
+//traceable:signature 453637adf078bf974a3c5ca84b255688
 
 package embedded_interface
 
@@ -6,26 +7,43 @@ import (
 	"context"
 
 	"github.com/ConorNevin/traceable/internal/tests/embedded_interface/nested"
-	"github.com/opentracing/opentracing-go"
+	"github.com/ConorNevin/traceable/runtime"
 )
 
 // TracedAnotherEmbedded is a traced implementation of AnotherEmbedded
 type TracedAnotherEmbedded struct {
 	x AnotherEmbedded
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedAnotherEmbedded returns a TracedAnotherEmbedded that wraps x.
+func NewTracedAnotherEmbedded(x AnotherEmbedded, opts ...runtime.Option) *TracedAnotherEmbedded {
+	return &TracedAnotherEmbedded{x: x, o: runtime.NewOptions(opts...)}
 }
 
-func (t *TracedAnotherEmbedded) FauxDu(a0 context.Context) (string, func() error, error) {
-	span, a0 := opentracing.StartSpanFromContext(a0, "AnotherEmbedded.FauxDu")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedAnotherEmbedded) FauxDu(a0 context.Context) (r0 string, r1 func() error, r2 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "FauxDu") {
+		return t.x.FauxDu(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "AnotherEmbedded.FauxDu")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "FauxDu", &r2)
 	return t.x.FauxDu(a0)
 }
 
-func (t *TracedAnotherEmbedded) Foo(a0 context.Context) nested.FauxReturn {
-	span, a0 := opentracing.StartSpanFromContext(a0, "AnotherEmbedded.Foo")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedAnotherEmbedded) Foo(a0 context.Context) (r0 nested.FauxReturn) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Foo") {
+		return t.x.Foo(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "AnotherEmbedded.Foo")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Foo", nil)
 	return t.x.Foo(a0)
 }
@@ -1,4 +1,5 @@
 // Code generated by "traceable -types Embedded -output embedded_types_traced.go"; DO NOT EDIT.
+//traceable:signature 236447c7df16b0779002ba6fb918553f
 
 package embedded_interface
 
@@ -7,34 +8,52 @@ import (
 	"io"
 	"net/http"
 
-	"github.com/opentracing/opentracing-go"
+	"github.com/ConorNevin/traceable/runtime"
 )
 
 // TracedEmbedded is a traced implementation of Embedded
 type TracedEmbedded struct {
 	x Embedded
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedEmbedded returns a TracedEmbedded that wraps x.
+func NewTracedEmbedded(x Embedded, opts ...runtime.Option) *TracedEmbedded {
+	return &TracedEmbedded{x: x, o: runtime.NewOptions(opts...)}
 }
 
-func (t *TracedEmbedded) FunctionOne(a0 context.Context, a1 func(context.Context, io.Reader) error) error {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Embedded.FunctionOne")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedEmbedded) FunctionOne(a0 context.Context, a1 func(context.Context, io.Reader) error) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "FunctionOne") {
+		return t.x.FunctionOne(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Embedded.FunctionOne")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "FunctionOne", &r0)
 	return t.x.FunctionOne(a0, a1)
 }
 
-func (t *TracedEmbedded) FunctionThree(a0 context.Context, a1 []http.Request) error {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Embedded.FunctionThree")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedEmbedded) FunctionThree(a0 context.Context, a1 []http.Request) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "FunctionThree") {
+		return t.x.FunctionThree(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Embedded.FunctionThree")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "FunctionThree", &r0)
 	return t.x.FunctionThree(a0, a1)
 }
 
-func (t *TracedEmbedded) FunctionTwo(a0 context.Context, a1 io.Writer) error {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Embedded.FunctionTwo")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedEmbedded) FunctionTwo(a0 context.Context, a1 io.Writer) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "FunctionTwo") {
+		return t.x.FunctionTwo(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Embedded.FunctionTwo")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "FunctionTwo", &r0)
 	return t.x.FunctionTwo(a0, a1)
 }
@@ -0,0 +1,61 @@
+// Code generated by "traceable -types Assigner -output assigner_traced.go -emit-tests -baggage-tags tenant.id,experiment.id -baggage-max-len 64"; DO NOT EDIT.
+//traceable:signature 2c15f2138ac6d3cf4898fd19799a60e9
+
+package experiments
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedAssigner is a traced implementation of Assigner
+type TracedAssigner struct {
+	x Assigner
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedAssigner returns a TracedAssigner that wraps x.
+func NewTracedAssigner(x Assigner, opts ...runtime.Option) *TracedAssigner {
+	return &TracedAssigner{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedAssigner) Assign(a0 context.Context, a1 string, a2 int64) (r0 *Assignment, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Assign") {
+		return t.x.Assign(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Assigner.Assign")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Assign", &r1)
+	runtime.SetBaggage(span, 64, "tenant.id", a1)
+	runtime.TagBaggage(span, 64, "tenant.id", "experiment.id")
+	return t.x.Assign(a0, a1, a2)
+}
+
+func (t *TracedAssigner) Record(a0 context.Context, a1 *Assignment) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Record") {
+		return t.x.Record(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Assigner.Record")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Record", &r0)
+	if a1 != nil && a1.Experiment != nil {
+		runtime.SetBaggage(span, 64, "experiment.id", a1.Experiment.ID)
+	}
+	if a1 != nil {
+		runtime.SetBaggage(span, 64, "variant", a1.Variant)
+	}
+	runtime.TagBaggage(span, 64, "tenant.id", "experiment.id")
+	return t.x.Record(a0, a1)
+}
+
+func (t *TracedAssigner) Variants(a0 string) []string {
+	return t.x.Variants(a0)
+}
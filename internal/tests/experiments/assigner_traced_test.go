@@ -0,0 +1,98 @@
+// Code generated by "traceable -types Assigner -output assigner_traced.go -emit-tests -baggage-tags tenant.id,experiment.id -baggage-max-len 64"; DO NOT EDIT.
+//traceable:signature 2c15f2138ac6d3cf4898fd19799a60e9
+
+package experiments
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingAssigner is a Assigner that records the calls made to it.
+type recordingAssigner struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Assigner = (*recordingAssigner)(nil)
+
+func (r *recordingAssigner) Assign(a0 context.Context, a1 string, a2 int64) (r0 *Assignment, r1 error) {
+	r.calls = append(r.calls, "Assign")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingAssigner) Record(a0 context.Context, a1 *Assignment) (r0 error) {
+	r.calls = append(r.calls, "Record")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingAssigner) Variants(a0 string) (r0 []string) {
+	r.calls = append(r.calls, "Variants")
+	r.ctxs = append(r.ctxs, nil)
+	return
+}
+
+func TestTracedAssigner(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedAssigner)
+	}{
+		{
+			method: "Assign",
+			traced: true,
+			call: func(ctx context.Context, x *TracedAssigner) {
+				x.Assign(ctx, *new(string), *new(int64))
+			},
+		},
+		{
+			method: "Record",
+			traced: true,
+			call: func(ctx context.Context, x *TracedAssigner) {
+				x.Record(ctx, *new(*Assignment))
+			},
+		},
+		{
+			method: "Variants",
+			traced: false,
+			call: func(ctx context.Context, x *TracedAssigner) {
+				x.Variants(*new(string))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingAssigner{}
+			tt.call(context.Background(), NewTracedAssigner(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Assigner." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package experiments
+
+import "context"
+
+//go:generate ../../../bin/traceable -types Assigner -output assigner_traced.go -emit-tests -baggage-tags tenant.id,experiment.id -baggage-max-len 64
+
+// Assignment is the variant of an experiment a user is assigned to.
+type Assignment struct {
+	Experiment *Experiment
+	Variant    string
+}
+
+// Experiment is an experiment users are assigned to.
+type Experiment struct {
+	ID string
+}
+
+type Assigner interface {
+	//traceable:baggage tenant.id=tenant
+	Assign(ctx context.Context, tenant string, user int64) (*Assignment, error)
+	//traceable:baggage experiment.id=a.Experiment.ID, variant=a.Variant
+	Record(ctx context.Context, a *Assignment) error
+	Variants(experiment string) []string
+}
@@ -1,23 +1,40 @@
-// Code generated by "traceable -types Geometry -output geometry_traced.go"; DO NOT EDIT.
+// Code generated by "traceable -types Geometry -output geometry_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature f17dcb33855ff7ffd13b226ef9e6baac
 
 package geometry
 
 import (
 	"context"
 
-	"github.com/opentracing/opentracing-go"
+	"github.com/ConorNevin/traceable/runtime"
 )
 
 // TracedGeometry is a traced implementation of Geometry
 type TracedGeometry struct {
 	x Geometry
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedGeometry returns a TracedGeometry that wraps x.
+func NewTracedGeometry(x Geometry, opts ...runtime.Option) *TracedGeometry {
+	return &TracedGeometry{x: x, o: runtime.NewOptions(opts...)}
 }
 
-func (t *TracedGeometry) Area(a0 context.Context) (float64, error) {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Geometry.Area")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedGeometry) Area(a0 context.Context) (r0 float64, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Area") {
+		return t.x.Area(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Geometry.Area")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Area", &r1)
 	return t.x.Area(a0)
 }
 
@@ -0,0 +1,85 @@
+// Code generated by "traceable -types Geometry -output geometry_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature f17dcb33855ff7ffd13b226ef9e6baac
+
+package geometry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingGeometry is a Geometry that records the calls made to it.
+type recordingGeometry struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Geometry = (*recordingGeometry)(nil)
+
+func (r *recordingGeometry) Area(a0 context.Context) (r0 float64, r1 error) {
+	r.calls = append(r.calls, "Area")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingGeometry) Height() (r0 float64) {
+	r.calls = append(r.calls, "Height")
+	r.ctxs = append(r.ctxs, nil)
+	return
+}
+
+func TestTracedGeometry(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedGeometry)
+	}{
+		{
+			method: "Area",
+			traced: true,
+			call: func(ctx context.Context, x *TracedGeometry) {
+				x.Area(ctx)
+			},
+		},
+		{
+			method: "Height",
+			traced: false,
+			call: func(ctx context.Context, x *TracedGeometry) {
+				x.Height()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingGeometry{}
+			tt.call(context.Background(), NewTracedGeometry(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Geometry." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
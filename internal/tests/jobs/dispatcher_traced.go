@@ -0,0 +1,55 @@
+// Code generated by "traceable -types Dispatcher -output dispatcher_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature ec9b5bdbc5d4b0594558ef40f9148da7
+
+package jobs
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// TracedDispatcher is a traced implementation of Dispatcher
+type TracedDispatcher struct {
+	x Dispatcher
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// tracedDispatcherTags are set on every span started by TracedDispatcher.
+var tracedDispatcherTags = opentracing.Tags{
+	"span.kind": ext.SpanKindEnum("producer"),
+}
+
+// NewTracedDispatcher returns a TracedDispatcher that wraps x.
+func NewTracedDispatcher(x Dispatcher, opts ...runtime.Option) *TracedDispatcher {
+	return &TracedDispatcher{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedDispatcher) Enqueue(a0 context.Context, a1 Job) (r0 string, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Enqueue") {
+		return t.x.Enqueue(a0, a1)
+	}
+	span, a0 := runtime.StartAsyncSpan(a0, t.o, "Dispatcher.Enqueue", tracedDispatcherTags)
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Enqueue", &r1)
+	return t.x.Enqueue(a0, a1)
+}
+
+func (t *TracedDispatcher) Status(a0 context.Context, a1 string) (r0 string, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Status") {
+		return t.x.Status(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Dispatcher.Status", tracedDispatcherTags)
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Status", &r1)
+	return t.x.Status(a0, a1)
+}
@@ -0,0 +1,90 @@
+// Code generated by "traceable -types Dispatcher -output dispatcher_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature ec9b5bdbc5d4b0594558ef40f9148da7
+
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingDispatcher is a Dispatcher that records the calls made to it.
+type recordingDispatcher struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Dispatcher = (*recordingDispatcher)(nil)
+
+func (r *recordingDispatcher) Enqueue(a0 context.Context, a1 Job) (r0 string, r1 error) {
+	r.calls = append(r.calls, "Enqueue")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingDispatcher) Status(a0 context.Context, a1 string) (r0 string, r1 error) {
+	r.calls = append(r.calls, "Status")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedDispatcher(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedDispatcher)
+	}{
+		{
+			method: "Enqueue",
+			traced: true,
+			call: func(ctx context.Context, x *TracedDispatcher) {
+				x.Enqueue(ctx, *new(Job))
+			},
+		},
+		{
+			method: "Status",
+			traced: true,
+			call: func(ctx context.Context, x *TracedDispatcher) {
+				x.Status(ctx, *new(string))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingDispatcher{}
+			tt.call(context.Background(), NewTracedDispatcher(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Dispatcher." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+			for tag, want := range tracedDispatcherTags {
+				if got := spans[0].Tag(tag); got != want {
+					t.Errorf("expected tag %s to be %v, got %v", tag, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import "context"
+
+//go:generate ../../../bin/traceable -types Dispatcher -output dispatcher_traced.go -emit-tests
+
+// Job is work run in the background.
+type Job struct {
+	Name string
+	Args []string
+}
+
+// Dispatcher submits jobs to be run in the background.
+//
+//traceable:kind producer
+type Dispatcher interface {
+	// Enqueue submits job and returns its ID without waiting for it to run.
+	//traceable:async
+	Enqueue(ctx context.Context, job Job) (string, error)
+	Status(ctx context.Context, id string) (string, error)
+}
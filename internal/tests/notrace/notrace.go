@@ -0,0 +1,14 @@
+package notrace
+
+import "context"
+
+//go:generate ../../../bin/traceable -types Queue -output queue_traced.go -emit-tests -notrace
+
+// Queue is used on a latency-critical path, so binaries built with the
+// notrace tag use a pass-through TracedQueue.
+type Queue interface {
+	Push(ctx context.Context, items ...[]byte) error
+	Pop(ctx context.Context) ([]byte, bool, error)
+	Len() int
+	Close()
+}
@@ -0,0 +1,59 @@
+// Code generated by "traceable -types Queue -output queue_traced.go -emit-tests -notrace"; DO NOT EDIT.
+//traceable:signature 1b1e164d2a855532a2992c628d1528e0
+
+//go:build !notrace
+// +build !notrace
+
+package notrace
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedQueue is a traced implementation of Queue
+type TracedQueue struct {
+	x Queue
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedQueue returns a TracedQueue that wraps x.
+func NewTracedQueue(x Queue, opts ...runtime.Option) *TracedQueue {
+	return &TracedQueue{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedQueue) Close() {
+	t.x.Close()
+}
+
+func (t *TracedQueue) Len() int {
+	return t.x.Len()
+}
+
+func (t *TracedQueue) Pop(a0 context.Context) (r0 []byte, r1 bool, r2 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Pop") {
+		return t.x.Pop(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Queue.Pop")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Pop", &r2)
+	return t.x.Pop(a0)
+}
+
+func (t *TracedQueue) Push(a0 context.Context, a1 ...[]byte) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Push") {
+		return t.x.Push(a0, a1...)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Queue.Push")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Push", &r0)
+	return t.x.Push(a0, a1...)
+}
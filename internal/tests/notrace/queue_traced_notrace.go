@@ -0,0 +1,45 @@
+// Code generated by "traceable -types Queue -output queue_traced.go -emit-tests -notrace"; DO NOT EDIT.
+//traceable:signature 1b1e164d2a855532a2992c628d1528e0
+
+//go:build notrace
+// +build notrace
+
+package notrace
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime/option"
+)
+
+// TracedQueue is an implementation of Queue that calls the wrapped value
+// directly, as tracing is compiled out by the notrace build tag.
+type TracedQueue struct {
+	x Queue
+
+	// ShouldTrace is never called. It is declared so that code setting it
+	// builds with and without the notrace build tag.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// NewTracedQueue returns a TracedQueue that wraps x. The options are
+// ignored.
+func NewTracedQueue(x Queue, _ ...option.Option) *TracedQueue {
+	return &TracedQueue{x: x}
+}
+
+func (t *TracedQueue) Close() {
+	t.x.Close()
+}
+
+func (t *TracedQueue) Len() int {
+	return t.x.Len()
+}
+
+func (t *TracedQueue) Pop(a0 context.Context) ([]byte, bool, error) {
+	return t.x.Pop(a0)
+}
+
+func (t *TracedQueue) Push(a0 context.Context, a1 ...[]byte) error {
+	return t.x.Push(a0, a1...)
+}
@@ -0,0 +1,114 @@
+// Code generated by "traceable -types Queue -output queue_traced.go -emit-tests -notrace"; DO NOT EDIT.
+//traceable:signature 1b1e164d2a855532a2992c628d1528e0
+
+//go:build !notrace
+// +build !notrace
+
+package notrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingQueue is a Queue that records the calls made to it.
+type recordingQueue struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Queue = (*recordingQueue)(nil)
+
+func (r *recordingQueue) Close() {
+	r.calls = append(r.calls, "Close")
+	r.ctxs = append(r.ctxs, nil)
+	return
+}
+
+func (r *recordingQueue) Len() (r0 int) {
+	r.calls = append(r.calls, "Len")
+	r.ctxs = append(r.ctxs, nil)
+	return
+}
+
+func (r *recordingQueue) Pop(a0 context.Context) (r0 []byte, r1 bool, r2 error) {
+	r.calls = append(r.calls, "Pop")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingQueue) Push(a0 context.Context, a1 ...[]byte) (r0 error) {
+	r.calls = append(r.calls, "Push")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedQueue(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedQueue)
+	}{
+		{
+			method: "Close",
+			traced: false,
+			call: func(ctx context.Context, x *TracedQueue) {
+				x.Close()
+			},
+		},
+		{
+			method: "Len",
+			traced: false,
+			call: func(ctx context.Context, x *TracedQueue) {
+				x.Len()
+			},
+		},
+		{
+			method: "Pop",
+			traced: true,
+			call: func(ctx context.Context, x *TracedQueue) {
+				x.Pop(ctx)
+			},
+		},
+		{
+			method: "Push",
+			traced: true,
+			call: func(ctx context.Context, x *TracedQueue) {
+				x.Push(ctx)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingQueue{}
+			tt.call(context.Background(), NewTracedQueue(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Queue." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
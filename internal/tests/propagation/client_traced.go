@@ -0,0 +1,74 @@
+// Code generated by "traceable -types Client -output client_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 58d0eac55739922beba10ef31df79d29
+
+package propagation
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// TracedClient is a traced implementation of Client
+type TracedClient struct {
+	x Client
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// tracedClientTags are set on every span started by TracedClient.
+var tracedClientTags = opentracing.Tags{
+	"span.kind": ext.SpanKindEnum("client"),
+}
+
+// NewTracedClient returns a TracedClient that wraps x.
+func NewTracedClient(x Client, opts ...runtime.Option) *TracedClient {
+	return &TracedClient{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedClient) Get(a0 context.Context, a1 string, a2 http.Header) (r0 []byte, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Get") {
+		return t.x.Get(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Client.Get", tracedClientTags)
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Get", &r1)
+	if a2 != nil {
+		runtime.Inject(span, t.o, opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(a2))
+	}
+	return t.x.Get(a0, a1, a2)
+}
+
+func (t *TracedClient) Publish(a0 context.Context, a1 string, a2 map[string]string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Publish") {
+		return t.x.Publish(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Client.Publish", tracedClientTags)
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Publish", &r0)
+	if a2 != nil {
+		runtime.Inject(span, t.o, opentracing.TextMap, opentracing.TextMapCarrier(a2))
+	}
+	return t.x.Publish(a0, a1, a2)
+}
+
+func (t *TracedClient) Send(a0 context.Context, a1 *Envelope) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Send") {
+		return t.x.Send(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Client.Send", tracedClientTags)
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Send", &r0)
+	if a1 != nil {
+		runtime.Inject(span, t.o, opentracing.TextMap, a1)
+	}
+	return t.x.Send(a0, a1)
+}
@@ -0,0 +1,104 @@
+// Code generated by "traceable -types Client -output client_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 58d0eac55739922beba10ef31df79d29
+
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingClient is a Client that records the calls made to it.
+type recordingClient struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Client = (*recordingClient)(nil)
+
+func (r *recordingClient) Get(a0 context.Context, a1 string, a2 http.Header) (r0 []byte, r1 error) {
+	r.calls = append(r.calls, "Get")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingClient) Publish(a0 context.Context, a1 string, a2 map[string]string) (r0 error) {
+	r.calls = append(r.calls, "Publish")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingClient) Send(a0 context.Context, a1 *Envelope) (r0 error) {
+	r.calls = append(r.calls, "Send")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedClient(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedClient)
+	}{
+		{
+			method: "Get",
+			traced: true,
+			call: func(ctx context.Context, x *TracedClient) {
+				x.Get(ctx, *new(string), *new(http.Header))
+			},
+		},
+		{
+			method: "Publish",
+			traced: true,
+			call: func(ctx context.Context, x *TracedClient) {
+				x.Publish(ctx, *new(string), *new(map[string]string))
+			},
+		},
+		{
+			method: "Send",
+			traced: true,
+			call: func(ctx context.Context, x *TracedClient) {
+				x.Send(ctx, *new(*Envelope))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingClient{}
+			tt.call(context.Background(), NewTracedClient(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Client." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+			for tag, want := range tracedClientTags {
+				if got := spans[0].Tag(tag); got != want {
+					t.Errorf("expected tag %s to be %v, got %v", tag, want, got)
+				}
+			}
+		})
+	}
+}
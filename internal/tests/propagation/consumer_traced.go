@@ -0,0 +1,46 @@
+// Code generated by "traceable -types Consumer -output consumer_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature af2fdcfa6e47616a7a307495306ba6ed
+
+package propagation
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// TracedConsumer is a traced implementation of Consumer
+type TracedConsumer struct {
+	x Consumer
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// tracedConsumerTags are set on every span started by TracedConsumer.
+var tracedConsumerTags = opentracing.Tags{
+	"span.kind": ext.SpanKindEnum("consumer"),
+}
+
+// NewTracedConsumer returns a TracedConsumer that wraps x.
+func NewTracedConsumer(x Consumer, opts ...runtime.Option) *TracedConsumer {
+	return &TracedConsumer{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedConsumer) Receive(a0 context.Context, a1 *Envelope) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Receive") {
+		return t.x.Receive(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Consumer.Receive", tracedConsumerTags, runtime.Extract(a0, t.o, opentracing.TextMap, a1))
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Receive", &r0)
+	return t.x.Receive(a0, a1)
+}
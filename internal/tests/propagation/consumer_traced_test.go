@@ -0,0 +1,77 @@
+// Code generated by "traceable -types Consumer -output consumer_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature af2fdcfa6e47616a7a307495306ba6ed
+
+package propagation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingConsumer is a Consumer that records the calls made to it.
+type recordingConsumer struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Consumer = (*recordingConsumer)(nil)
+
+func (r *recordingConsumer) Receive(a0 context.Context, a1 *Envelope) (r0 error) {
+	r.calls = append(r.calls, "Receive")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedConsumer(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedConsumer)
+	}{
+		{
+			method: "Receive",
+			traced: true,
+			call: func(ctx context.Context, x *TracedConsumer) {
+				x.Receive(ctx, *new(*Envelope))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingConsumer{}
+			tt.call(context.Background(), NewTracedConsumer(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Consumer." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+			for tag, want := range tracedConsumerTags {
+				if got := spans[0].Tag(tag); got != want {
+					t.Errorf("expected tag %s to be %v, got %v", tag, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Code generated by "traceable -types Handler -output handler_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 62b94206e954c10a2e12960889627769
+
+package propagation
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// TracedHandler is a traced implementation of Handler
+type TracedHandler struct {
+	x Handler
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// tracedHandlerTags are set on every span started by TracedHandler.
+var tracedHandlerTags = opentracing.Tags{
+	"span.kind": ext.SpanKindEnum("server"),
+}
+
+// NewTracedHandler returns a TracedHandler that wraps x.
+func NewTracedHandler(x Handler, opts ...runtime.Option) *TracedHandler {
+	return &TracedHandler{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedHandler) Serve(a0 context.Context, a1 http.Header, a2 []byte) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Serve") {
+		return t.x.Serve(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Handler.Serve", tracedHandlerTags, runtime.Extract(a0, t.o, opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(a1)))
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Serve", &r0)
+	return t.x.Serve(a0, a1, a2)
+}
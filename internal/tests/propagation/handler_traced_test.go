@@ -0,0 +1,78 @@
+// Code generated by "traceable -types Handler -output handler_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 62b94206e954c10a2e12960889627769
+
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingHandler is a Handler that records the calls made to it.
+type recordingHandler struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Handler = (*recordingHandler)(nil)
+
+func (r *recordingHandler) Serve(a0 context.Context, a1 http.Header, a2 []byte) (r0 error) {
+	r.calls = append(r.calls, "Serve")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedHandler(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedHandler)
+	}{
+		{
+			method: "Serve",
+			traced: true,
+			call: func(ctx context.Context, x *TracedHandler) {
+				x.Serve(ctx, *new(http.Header), *new([]byte))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingHandler{}
+			tt.call(context.Background(), NewTracedHandler(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Handler." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+			for tag, want := range tracedHandlerTags {
+				if got := spans[0].Tag(tag); got != want {
+					t.Errorf("expected tag %s to be %v, got %v", tag, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package propagation
+
+import (
+	"context"
+	"net/http"
+)
+
+//go:generate ../../../bin/traceable -types Client -output client_traced.go -emit-tests
+//go:generate ../../../bin/traceable -types Handler -output handler_traced.go -emit-tests
+//go:generate ../../../bin/traceable -types Consumer -output consumer_traced.go -emit-tests
+
+// Client sends requests and messages to other services.
+//
+//traceable:kind client
+type Client interface {
+	Get(ctx context.Context, url string, header http.Header) ([]byte, error)
+	Publish(ctx context.Context, topic string, attributes map[string]string) error
+	Send(ctx context.Context, envelope *Envelope) error
+}
+
+// Handler handles requests sent by a Client.
+//
+//traceable:kind server
+type Handler interface {
+	Serve(ctx context.Context, header http.Header, body []byte) error
+}
+
+// Consumer receives the envelopes published by a Client. The envelope may
+// be nil.
+//
+//traceable:kind consumer
+type Consumer interface {
+	Receive(ctx context.Context, envelope *Envelope) error
+}
+
+// Envelope is a message whose attributes carry span contexts.
+//
+//traceable:carrier
+type Envelope struct {
+	Attributes map[string]string
+	Body       []byte
+}
+
+// Set implements opentracing.TextMapWriter.
+func (e *Envelope) Set(key, val string) {
+	if e.Attributes == nil {
+		e.Attributes = make(map[string]string)
+	}
+	e.Attributes[key] = val
+}
+
+// ForeachKey implements opentracing.TextMapReader.
+func (e *Envelope) ForeachKey(handler func(key, val string) error) error {
+	for key, val := range e.Attributes {
+		if err := handler(key, val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// Code generated by "traceable -types Index -output index_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature f01d633951b14f8bcfbf2d710d30a918
+
+package query
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedIndex is a traced implementation of Index
+type TracedIndex struct {
+	x Index
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedIndex returns a TracedIndex that wraps x.
+func NewTracedIndex(x Index, opts ...runtime.Option) *TracedIndex {
+	return &TracedIndex{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedIndex) Count(a0 context.Context, a1 SearchRequest) (r0 int, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Count") {
+		return t.x.Count(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Index.Count")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Count", &r1)
+	runtime.Tag(span, "search.query", a1.Query)
+	if a1.Page != nil {
+		runtime.Tag(span, "search.page.size", a1.Page.Size)
+	}
+	if a1.Page != nil && a1.Page.Cursor != nil {
+		runtime.Tag(span, "search.page.offset", a1.Page.Cursor.Offset)
+	}
+	if a1.Filters.Region != "" {
+		runtime.Tag(span, "search.region", a1.Filters.Region)
+	}
+	return t.x.Count(a0, a1)
+}
+
+func (t *TracedIndex) Search(a0 context.Context, a1 *SearchRequest) (r0 []string, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Search") {
+		return t.x.Search(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Index.Search")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Search", &r1)
+	if a1 != nil {
+		runtime.Tag(span, "search.query", a1.Query)
+	}
+	if a1 != nil && a1.Page != nil {
+		runtime.Tag(span, "search.page.size", a1.Page.Size)
+	}
+	if a1 != nil && a1.Page != nil && a1.Page.Cursor != nil {
+		runtime.Tag(span, "search.page.offset", a1.Page.Cursor.Offset)
+	}
+	if a1 != nil && a1.Filters.Region != "" {
+		runtime.Tag(span, "search.region", a1.Filters.Region)
+	}
+	return t.x.Search(a0, a1)
+}
@@ -0,0 +1,85 @@
+// Code generated by "traceable -types Index -output index_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature f01d633951b14f8bcfbf2d710d30a918
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingIndex is a Index that records the calls made to it.
+type recordingIndex struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Index = (*recordingIndex)(nil)
+
+func (r *recordingIndex) Count(a0 context.Context, a1 SearchRequest) (r0 int, r1 error) {
+	r.calls = append(r.calls, "Count")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingIndex) Search(a0 context.Context, a1 *SearchRequest) (r0 []string, r1 error) {
+	r.calls = append(r.calls, "Search")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedIndex(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedIndex)
+	}{
+		{
+			method: "Count",
+			traced: true,
+			call: func(ctx context.Context, x *TracedIndex) {
+				x.Count(ctx, *new(SearchRequest))
+			},
+		},
+		{
+			method: "Search",
+			traced: true,
+			call: func(ctx context.Context, x *TracedIndex) {
+				x.Search(ctx, *new(*SearchRequest))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingIndex{}
+			tt.call(context.Background(), NewTracedIndex(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Index." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
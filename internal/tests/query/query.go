@@ -0,0 +1,34 @@
+package query
+
+import "context"
+
+//go:generate ../../../bin/traceable -types Index -output index_traced.go -emit-tests
+
+type Index interface {
+	Search(ctx context.Context, req *SearchRequest) ([]string, error)
+	Count(ctx context.Context, req SearchRequest) (int, error)
+}
+
+type SearchRequest struct {
+	Query string `trace:"search.query"`
+	Page  *Page
+	Filters
+	// Session is not recorded as it has no trace struct tag.
+	Session string
+	limit   int `trace:"search.limit"`
+}
+
+type Filters struct {
+	Region string `trace:"search.region,omitempty"`
+}
+
+type Page struct {
+	Size   int `trace:"search.page.size"`
+	Cursor *Cursor
+}
+
+type Cursor struct {
+	Offset int `trace:"search.page.offset"`
+	// Next is too deeply nested to be recorded.
+	Next *Cursor
+}
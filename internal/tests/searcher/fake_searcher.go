@@ -0,0 +1,492 @@
+// Code generated by "traceable -types Searcher -fake -output fake_searcher.go"; DO NOT EDIT.
+//traceable:signature bddf00b24307935b2a9066c8731c1dbf
+
+package searcher
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeSearcher is a fake implementation of Searcher that records the calls made to it.
+type FakeSearcher struct {
+	mu sync.Mutex
+
+	// ManyFunc, if set, is called by Many.
+	ManyFunc    func(context.Context, map[int]string) Errors
+	callsMany   []FakeSearcherManyCall
+	returnsMany struct {
+		r0 Errors
+	}
+
+	// OneFunc, if set, is called by One.
+	OneFunc    func(context.Context, int, int, string) error
+	callsOne   []FakeSearcherOneCall
+	returnsOne struct {
+		r0 error
+	}
+
+	// SearchFunc, if set, is called by Search.
+	SearchFunc    func(context.Context, string) error
+	callsSearch   []FakeSearcherSearchCall
+	returnsSearch struct {
+		r0 error
+	}
+
+	// SearchAllFunc, if set, is called by SearchAll.
+	SearchAllFunc    func(context.Context, ...string) (chan<- string, error)
+	callsSearchAll   []FakeSearcherSearchAllCall
+	returnsSearchAll struct {
+		r0 chan<- string
+		r1 error
+	}
+
+	// StoreAllFunc, if set, is called by StoreAll.
+	StoreAllFunc    func(context.Context, <-chan string) error
+	callsStoreAll   []FakeSearcherStoreAllCall
+	returnsStoreAll struct {
+		r0 error
+	}
+
+	// StoreAnythingFunc, if set, is called by StoreAnything.
+	StoreAnythingFunc    func(context.Context, interface{}) error
+	callsStoreAnything   []FakeSearcherStoreAnythingCall
+	returnsStoreAnything struct {
+		r0 error
+	}
+
+	// StoreInterfaceFunc, if set, is called by StoreInterface.
+	StoreInterfaceFunc    func(context.Context, Stringer) (int, error)
+	callsStoreInterface   []FakeSearcherStoreInterfaceCall
+	returnsStoreInterface struct {
+		r0 int
+		r1 error
+	}
+
+	// StoreMapFunc, if set, is called by StoreMap.
+	StoreMapFunc    func(context.Context, map[int8]string) error
+	callsStoreMap   []FakeSearcherStoreMapCall
+	returnsStoreMap struct {
+		r0 error
+	}
+}
+
+var _ Searcher = (*FakeSearcher)(nil)
+
+// FakeSearcherManyCall holds the arguments of a call to FakeSearcher.Many.
+type FakeSearcherManyCall struct {
+	A0 context.Context
+	A1 map[int]string
+}
+
+// FakeSearcherOneCall holds the arguments of a call to FakeSearcher.One.
+type FakeSearcherOneCall struct {
+	A0 context.Context
+	A1 int
+	A2 int
+	A3 string
+}
+
+// FakeSearcherSearchCall holds the arguments of a call to FakeSearcher.Search.
+type FakeSearcherSearchCall struct {
+	A0 context.Context
+	A1 string
+}
+
+// FakeSearcherSearchAllCall holds the arguments of a call to FakeSearcher.SearchAll.
+type FakeSearcherSearchAllCall struct {
+	A0 context.Context
+	A1 []string
+}
+
+// FakeSearcherStoreAllCall holds the arguments of a call to FakeSearcher.StoreAll.
+type FakeSearcherStoreAllCall struct {
+	A0 context.Context
+	A1 <-chan string
+}
+
+// FakeSearcherStoreAnythingCall holds the arguments of a call to FakeSearcher.StoreAnything.
+type FakeSearcherStoreAnythingCall struct {
+	A0 context.Context
+	A1 interface{}
+}
+
+// FakeSearcherStoreInterfaceCall holds the arguments of a call to FakeSearcher.StoreInterface.
+type FakeSearcherStoreInterfaceCall struct {
+	A0 context.Context
+	A1 Stringer
+}
+
+// FakeSearcherStoreMapCall holds the arguments of a call to FakeSearcher.StoreMap.
+type FakeSearcherStoreMapCall struct {
+	A0 context.Context
+	A1 map[int8]string
+}
+
+// Many implements Searcher.
+func (f *FakeSearcher) Many(a0 context.Context, a1 map[int]string) Errors {
+	f.mu.Lock()
+	f.callsMany = append(f.callsMany, FakeSearcherManyCall{A0: a0, A1: a1})
+	fn := f.ManyFunc
+	returns := f.returnsMany
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1)
+	}
+	return returns.r0
+}
+
+// ManyReturns sets the values returned by Many when ManyFunc is not set.
+func (f *FakeSearcher) ManyReturns(r0 Errors) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsMany.r0 = r0
+}
+
+// ManyCalls returns the arguments of each call made to Many.
+func (f *FakeSearcher) ManyCalls() []FakeSearcherManyCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeSearcherManyCall(nil), f.callsMany...)
+}
+
+// ManyCallCount returns the number of calls made to Many.
+func (f *FakeSearcher) ManyCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsMany)
+}
+
+// AssertManyCallCount fails the test unless Many was called want times.
+func (f *FakeSearcher) AssertManyCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.ManyCallCount(); got != want {
+		t.Errorf("expected FakeSearcher.Many to be called %d times, got %d", want, got)
+	}
+}
+
+// One implements Searcher.
+func (f *FakeSearcher) One(a0 context.Context, a1 int, a2 int, a3 string) error {
+	f.mu.Lock()
+	f.callsOne = append(f.callsOne, FakeSearcherOneCall{A0: a0, A1: a1, A2: a2, A3: a3})
+	fn := f.OneFunc
+	returns := f.returnsOne
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1, a2, a3)
+	}
+	return returns.r0
+}
+
+// OneReturns sets the values returned by One when OneFunc is not set.
+func (f *FakeSearcher) OneReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsOne.r0 = r0
+}
+
+// OneCalls returns the arguments of each call made to One.
+func (f *FakeSearcher) OneCalls() []FakeSearcherOneCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeSearcherOneCall(nil), f.callsOne...)
+}
+
+// OneCallCount returns the number of calls made to One.
+func (f *FakeSearcher) OneCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsOne)
+}
+
+// AssertOneCallCount fails the test unless One was called want times.
+func (f *FakeSearcher) AssertOneCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.OneCallCount(); got != want {
+		t.Errorf("expected FakeSearcher.One to be called %d times, got %d", want, got)
+	}
+}
+
+// Search implements Searcher.
+func (f *FakeSearcher) Search(a0 context.Context, a1 string) error {
+	f.mu.Lock()
+	f.callsSearch = append(f.callsSearch, FakeSearcherSearchCall{A0: a0, A1: a1})
+	fn := f.SearchFunc
+	returns := f.returnsSearch
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1)
+	}
+	return returns.r0
+}
+
+// SearchReturns sets the values returned by Search when SearchFunc is not set.
+func (f *FakeSearcher) SearchReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsSearch.r0 = r0
+}
+
+// SearchCalls returns the arguments of each call made to Search.
+func (f *FakeSearcher) SearchCalls() []FakeSearcherSearchCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeSearcherSearchCall(nil), f.callsSearch...)
+}
+
+// SearchCallCount returns the number of calls made to Search.
+func (f *FakeSearcher) SearchCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsSearch)
+}
+
+// AssertSearchCallCount fails the test unless Search was called want times.
+func (f *FakeSearcher) AssertSearchCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.SearchCallCount(); got != want {
+		t.Errorf("expected FakeSearcher.Search to be called %d times, got %d", want, got)
+	}
+}
+
+// SearchAll implements Searcher.
+func (f *FakeSearcher) SearchAll(a0 context.Context, a1 ...string) (chan<- string, error) {
+	f.mu.Lock()
+	f.callsSearchAll = append(f.callsSearchAll, FakeSearcherSearchAllCall{A0: a0, A1: a1})
+	fn := f.SearchAllFunc
+	returns := f.returnsSearchAll
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1...)
+	}
+	return returns.r0, returns.r1
+}
+
+// SearchAllReturns sets the values returned by SearchAll when SearchAllFunc is not set.
+func (f *FakeSearcher) SearchAllReturns(r0 chan<- string, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsSearchAll.r0, f.returnsSearchAll.r1 = r0, r1
+}
+
+// SearchAllCalls returns the arguments of each call made to SearchAll.
+func (f *FakeSearcher) SearchAllCalls() []FakeSearcherSearchAllCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeSearcherSearchAllCall(nil), f.callsSearchAll...)
+}
+
+// SearchAllCallCount returns the number of calls made to SearchAll.
+func (f *FakeSearcher) SearchAllCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsSearchAll)
+}
+
+// AssertSearchAllCallCount fails the test unless SearchAll was called want times.
+func (f *FakeSearcher) AssertSearchAllCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.SearchAllCallCount(); got != want {
+		t.Errorf("expected FakeSearcher.SearchAll to be called %d times, got %d", want, got)
+	}
+}
+
+// StoreAll implements Searcher.
+func (f *FakeSearcher) StoreAll(a0 context.Context, a1 <-chan string) error {
+	f.mu.Lock()
+	f.callsStoreAll = append(f.callsStoreAll, FakeSearcherStoreAllCall{A0: a0, A1: a1})
+	fn := f.StoreAllFunc
+	returns := f.returnsStoreAll
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1)
+	}
+	return returns.r0
+}
+
+// StoreAllReturns sets the values returned by StoreAll when StoreAllFunc is not set.
+func (f *FakeSearcher) StoreAllReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsStoreAll.r0 = r0
+}
+
+// StoreAllCalls returns the arguments of each call made to StoreAll.
+func (f *FakeSearcher) StoreAllCalls() []FakeSearcherStoreAllCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeSearcherStoreAllCall(nil), f.callsStoreAll...)
+}
+
+// StoreAllCallCount returns the number of calls made to StoreAll.
+func (f *FakeSearcher) StoreAllCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsStoreAll)
+}
+
+// AssertStoreAllCallCount fails the test unless StoreAll was called want times.
+func (f *FakeSearcher) AssertStoreAllCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.StoreAllCallCount(); got != want {
+		t.Errorf("expected FakeSearcher.StoreAll to be called %d times, got %d", want, got)
+	}
+}
+
+// StoreAnything implements Searcher.
+func (f *FakeSearcher) StoreAnything(a0 context.Context, a1 interface{}) error {
+	f.mu.Lock()
+	f.callsStoreAnything = append(f.callsStoreAnything, FakeSearcherStoreAnythingCall{A0: a0, A1: a1})
+	fn := f.StoreAnythingFunc
+	returns := f.returnsStoreAnything
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1)
+	}
+	return returns.r0
+}
+
+// StoreAnythingReturns sets the values returned by StoreAnything when StoreAnythingFunc is not set.
+func (f *FakeSearcher) StoreAnythingReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsStoreAnything.r0 = r0
+}
+
+// StoreAnythingCalls returns the arguments of each call made to StoreAnything.
+func (f *FakeSearcher) StoreAnythingCalls() []FakeSearcherStoreAnythingCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeSearcherStoreAnythingCall(nil), f.callsStoreAnything...)
+}
+
+// StoreAnythingCallCount returns the number of calls made to StoreAnything.
+func (f *FakeSearcher) StoreAnythingCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsStoreAnything)
+}
+
+// AssertStoreAnythingCallCount fails the test unless StoreAnything was called want times.
+func (f *FakeSearcher) AssertStoreAnythingCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.StoreAnythingCallCount(); got != want {
+		t.Errorf("expected FakeSearcher.StoreAnything to be called %d times, got %d", want, got)
+	}
+}
+
+// StoreInterface implements Searcher.
+func (f *FakeSearcher) StoreInterface(a0 context.Context, a1 Stringer) (int, error) {
+	f.mu.Lock()
+	f.callsStoreInterface = append(f.callsStoreInterface, FakeSearcherStoreInterfaceCall{A0: a0, A1: a1})
+	fn := f.StoreInterfaceFunc
+	returns := f.returnsStoreInterface
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1)
+	}
+	return returns.r0, returns.r1
+}
+
+// StoreInterfaceReturns sets the values returned by StoreInterface when StoreInterfaceFunc is not set.
+func (f *FakeSearcher) StoreInterfaceReturns(r0 int, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsStoreInterface.r0, f.returnsStoreInterface.r1 = r0, r1
+}
+
+// StoreInterfaceCalls returns the arguments of each call made to StoreInterface.
+func (f *FakeSearcher) StoreInterfaceCalls() []FakeSearcherStoreInterfaceCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeSearcherStoreInterfaceCall(nil), f.callsStoreInterface...)
+}
+
+// StoreInterfaceCallCount returns the number of calls made to StoreInterface.
+func (f *FakeSearcher) StoreInterfaceCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsStoreInterface)
+}
+
+// AssertStoreInterfaceCallCount fails the test unless StoreInterface was called want times.
+func (f *FakeSearcher) AssertStoreInterfaceCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.StoreInterfaceCallCount(); got != want {
+		t.Errorf("expected FakeSearcher.StoreInterface to be called %d times, got %d", want, got)
+	}
+}
+
+// StoreMap implements Searcher.
+func (f *FakeSearcher) StoreMap(a0 context.Context, a1 map[int8]string) error {
+	f.mu.Lock()
+	f.callsStoreMap = append(f.callsStoreMap, FakeSearcherStoreMapCall{A0: a0, A1: a1})
+	fn := f.StoreMapFunc
+	returns := f.returnsStoreMap
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1)
+	}
+	return returns.r0
+}
+
+// StoreMapReturns sets the values returned by StoreMap when StoreMapFunc is not set.
+func (f *FakeSearcher) StoreMapReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsStoreMap.r0 = r0
+}
+
+// StoreMapCalls returns the arguments of each call made to StoreMap.
+func (f *FakeSearcher) StoreMapCalls() []FakeSearcherStoreMapCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeSearcherStoreMapCall(nil), f.callsStoreMap...)
+}
+
+// StoreMapCallCount returns the number of calls made to StoreMap.
+func (f *FakeSearcher) StoreMapCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsStoreMap)
+}
+
+// AssertStoreMapCallCount fails the test unless StoreMap was called want times.
+func (f *FakeSearcher) AssertStoreMapCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.StoreMapCallCount(); got != want {
+		t.Errorf("expected FakeSearcher.StoreMap to be called %d times, got %d", want, got)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 )
 
-//go:generate ../../../bin/traceable -types Searcher -output searcher_traced.go
+//go:generate ../../../bin/traceable -types Searcher -output searcher_traced.go -emit-tests
+//go:generate ../../../bin/traceable -types Searcher -fake -output fake_searcher.go
+//go:generate ../../../bin/traceable -types Searcher -record-sizes -output sized/searcher.go -emit-tests
 
 type Stringer interface {
 	String() error
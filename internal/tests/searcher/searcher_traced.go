@@ -1,78 +1,102 @@
-// Code generated by "traceable -types Searcher -output searcher_traced.go"; DO NOT EDIT.
+// Code generated by "traceable -types Searcher -output searcher_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 520b429bec919b9898224dcb851047eb
 
 package searcher
 
 import (
 	"context"
 
-	"github.com/opentracing/opentracing-go"
+	"github.com/ConorNevin/traceable/runtime"
 )
 
 // TracedSearcher is a traced implementation of Searcher
 type TracedSearcher struct {
 	x Searcher
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedSearcher returns a TracedSearcher that wraps x.
+func NewTracedSearcher(x Searcher, opts ...runtime.Option) *TracedSearcher {
+	return &TracedSearcher{x: x, o: runtime.NewOptions(opts...)}
 }
 
-func (t *TracedSearcher) Many(a0 context.Context, a1 map[int]string) Errors {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Searcher.Many")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedSearcher) Many(a0 context.Context, a1 map[int]string) (r0 Errors) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Many") {
+		return t.x.Many(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Searcher.Many")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Many", nil)
 	return t.x.Many(a0, a1)
 }
 
-func (t *TracedSearcher) One(a0 context.Context, a1 int, a2 int, a3 string) error {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Searcher.One")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedSearcher) One(a0 context.Context, a1 int, a2 int, a3 string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "One") {
+		return t.x.One(a0, a1, a2, a3)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Searcher.One")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "One", &r0)
 	return t.x.One(a0, a1, a2, a3)
 }
 
-func (t *TracedSearcher) Search(a0 context.Context, a1 string) error {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Searcher.Search")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedSearcher) Search(a0 context.Context, a1 string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Search") {
+		return t.x.Search(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Searcher.Search")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Search", &r0)
 	return t.x.Search(a0, a1)
 }
 
-func (t *TracedSearcher) SearchAll(a0 context.Context, a1 ...string) (chan<- string, error) {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Searcher.SearchAll")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedSearcher) SearchAll(a0 context.Context, a1 ...string) (r0 chan<- string, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "SearchAll") {
+		return t.x.SearchAll(a0, a1...)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Searcher.SearchAll")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "SearchAll", &r1)
 	return t.x.SearchAll(a0, a1...)
 }
 
-func (t *TracedSearcher) StoreAll(a0 context.Context, a1 <-chan string) error {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Searcher.StoreAll")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedSearcher) StoreAll(a0 context.Context, a1 <-chan string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "StoreAll") {
+		return t.x.StoreAll(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Searcher.StoreAll")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "StoreAll", &r0)
 	return t.x.StoreAll(a0, a1)
 }
 
-func (t *TracedSearcher) StoreAnything(a0 context.Context, a1 interface{}) error {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Searcher.StoreAnything")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedSearcher) StoreAnything(a0 context.Context, a1 interface{}) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "StoreAnything") {
+		return t.x.StoreAnything(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Searcher.StoreAnything")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "StoreAnything", &r0)
 	return t.x.StoreAnything(a0, a1)
 }
 
-func (t *TracedSearcher) StoreInterface(a0 context.Context, a1 Stringer) (int, error) {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Searcher.StoreInterface")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedSearcher) StoreInterface(a0 context.Context, a1 Stringer) (r0 int, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "StoreInterface") {
+		return t.x.StoreInterface(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Searcher.StoreInterface")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "StoreInterface", &r1)
 	return t.x.StoreInterface(a0, a1)
 }
 
-func (t *TracedSearcher) StoreMap(a0 context.Context, a1 map[int8]string) error {
-	span, a0 := opentracing.StartSpanFromContext(a0, "Searcher.StoreMap")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedSearcher) StoreMap(a0 context.Context, a1 map[int8]string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "StoreMap") {
+		return t.x.StoreMap(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Searcher.StoreMap")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "StoreMap", &r0)
 	return t.x.StoreMap(a0, a1)
 }
@@ -0,0 +1,163 @@
+// Code generated by "traceable -types Searcher -output searcher_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 520b429bec919b9898224dcb851047eb
+
+package searcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingSearcher is a Searcher that records the calls made to it.
+type recordingSearcher struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Searcher = (*recordingSearcher)(nil)
+
+func (r *recordingSearcher) Many(a0 context.Context, a1 map[int]string) (r0 Errors) {
+	r.calls = append(r.calls, "Many")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingSearcher) One(a0 context.Context, a1 int, a2 int, a3 string) (r0 error) {
+	r.calls = append(r.calls, "One")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingSearcher) Search(a0 context.Context, a1 string) (r0 error) {
+	r.calls = append(r.calls, "Search")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingSearcher) SearchAll(a0 context.Context, a1 ...string) (r0 chan<- string, r1 error) {
+	r.calls = append(r.calls, "SearchAll")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingSearcher) StoreAll(a0 context.Context, a1 <-chan string) (r0 error) {
+	r.calls = append(r.calls, "StoreAll")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingSearcher) StoreAnything(a0 context.Context, a1 interface{}) (r0 error) {
+	r.calls = append(r.calls, "StoreAnything")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingSearcher) StoreInterface(a0 context.Context, a1 Stringer) (r0 int, r1 error) {
+	r.calls = append(r.calls, "StoreInterface")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingSearcher) StoreMap(a0 context.Context, a1 map[int8]string) (r0 error) {
+	r.calls = append(r.calls, "StoreMap")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedSearcher(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedSearcher)
+	}{
+		{
+			method: "Many",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSearcher) {
+				x.Many(ctx, *new(map[int]string))
+			},
+		},
+		{
+			method: "One",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSearcher) {
+				x.One(ctx, *new(int), *new(int), *new(string))
+			},
+		},
+		{
+			method: "Search",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSearcher) {
+				x.Search(ctx, *new(string))
+			},
+		},
+		{
+			method: "SearchAll",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSearcher) {
+				x.SearchAll(ctx)
+			},
+		},
+		{
+			method: "StoreAll",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSearcher) {
+				x.StoreAll(ctx, *new(<-chan string))
+			},
+		},
+		{
+			method: "StoreAnything",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSearcher) {
+				x.StoreAnything(ctx, *new(interface{}))
+			},
+		},
+		{
+			method: "StoreInterface",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSearcher) {
+				x.StoreInterface(ctx, *new(Stringer))
+			},
+		},
+		{
+			method: "StoreMap",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSearcher) {
+				x.StoreMap(ctx, *new(map[int8]string))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingSearcher{}
+			tt.call(context.Background(), NewTracedSearcher(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Searcher." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
 package subpackage
 
-//go:generate ../../../bin/traceable -types FooBar -output traced/foobar.go
+//go:generate ../../../bin/traceable -types FooBar -output traced/foobar.go -emit-tests
 
 import (
 	"context"
@@ -1,4 +1,5 @@
-// Code generated by "traceable -types FooBar -output traced/foobar.go"; DO NOT EDIT.
+// Code generated by "traceable -types FooBar -output traced/foobar.go -emit-tests"; DO NOT EDIT.
+//traceable:signature b23c2cb52cbbd3ad59a67dcc8350bea6
 
 package traced
 
@@ -6,18 +7,34 @@ import (
 	"context"
 
 	"github.com/ConorNevin/traceable/internal/tests/subpackage"
-	"github.com/opentracing/opentracing-go"
+	"github.com/ConorNevin/traceable/runtime"
 )
 
 // TracedFooBar is a traced implementation of FooBar
 type TracedFooBar struct {
 	x subpackage.FooBar
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedFooBar returns a TracedFooBar that wraps x.
+func NewTracedFooBar(x subpackage.FooBar, opts ...runtime.Option) *TracedFooBar {
+	return &TracedFooBar{x: x, o: runtime.NewOptions(opts...)}
 }
 
-func (t *TracedFooBar) Foo(a0 context.Context) error {
-	span, a0 := opentracing.StartSpanFromContext(a0, "FooBar.Foo")
-	defer func() {
-		span.Finish()
-	}()
+func (t *TracedFooBar) Foo(a0 context.Context) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Foo") {
+		return t.x.Foo(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "FooBar.Foo")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Foo", &r0)
 	return t.x.Foo(a0)
 }
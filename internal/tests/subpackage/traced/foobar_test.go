@@ -0,0 +1,73 @@
+// Code generated by "traceable -types FooBar -output traced/foobar.go -emit-tests"; DO NOT EDIT.
+//traceable:signature b23c2cb52cbbd3ad59a67dcc8350bea6
+
+package traced
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/internal/tests/subpackage"
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingFooBar is a subpackage.FooBar that records the calls made to it.
+type recordingFooBar struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ subpackage.FooBar = (*recordingFooBar)(nil)
+
+func (r *recordingFooBar) Foo(a0 context.Context) (r0 error) {
+	r.calls = append(r.calls, "Foo")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedFooBar(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedFooBar)
+	}{
+		{
+			method: "Foo",
+			traced: true,
+			call: func(ctx context.Context, x *TracedFooBar) {
+				x.Foo(ctx)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingFooBar{}
+			tt.call(context.Background(), NewTracedFooBar(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "FooBar." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
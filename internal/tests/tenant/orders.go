@@ -0,0 +1,19 @@
+package tenant
+
+import "context"
+
+//go:generate ../../../bin/traceable -types Orders -output orders_traced.go -emit-tests -context-tags tenant.id=github.com/ConorNevin/traceable/internal/tests/tenant/auth.TenantFromContext,request.id=github.com/ConorNevin/traceable/internal/tests/tenant.requestID
+
+type requestIDKey struct{}
+
+// requestID returns the ID of the request ctx belongs to.
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type Orders interface {
+	Place(ctx context.Context, sku string, quantity int) (string, error)
+	Cancel(ctx context.Context, order string) error
+	Count() int
+}
@@ -0,0 +1,61 @@
+// Code generated by "traceable -types Orders -output orders_traced.go -emit-tests -context-tags tenant.id=github.com/ConorNevin/traceable/internal/tests/tenant/auth.TenantFromContext,request.id=github.com/ConorNevin/traceable/internal/tests/tenant.requestID"; DO NOT EDIT.
+//traceable:signature d36f00d253fdb417686e4fadf8cf5470
+
+package tenant
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/internal/tests/tenant/auth"
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedOrders is a traced implementation of Orders
+type TracedOrders struct {
+	x Orders
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedOrders returns a TracedOrders that wraps x.
+func NewTracedOrders(x Orders, opts ...runtime.Option) *TracedOrders {
+	return &TracedOrders{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedOrders) Cancel(a0 context.Context, a1 string) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Cancel") {
+		return t.x.Cancel(a0, a1)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Orders.Cancel")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Cancel", &r0)
+	if v, ok := auth.TenantFromContext(a0); ok {
+		runtime.Tag(span, "tenant.id", v)
+	}
+	runtime.Tag(span, "request.id", requestID(a0))
+	return t.x.Cancel(a0, a1)
+}
+
+func (t *TracedOrders) Count() int {
+	return t.x.Count()
+}
+
+func (t *TracedOrders) Place(a0 context.Context, a1 string, a2 int) (r0 string, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Place") {
+		return t.x.Place(a0, a1, a2)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Orders.Place")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Place", &r1)
+	if v, ok := auth.TenantFromContext(a0); ok {
+		runtime.Tag(span, "tenant.id", v)
+	}
+	runtime.Tag(span, "request.id", requestID(a0))
+	return t.x.Place(a0, a1, a2)
+}
@@ -0,0 +1,98 @@
+// Code generated by "traceable -types Orders -output orders_traced.go -emit-tests -context-tags tenant.id=github.com/ConorNevin/traceable/internal/tests/tenant/auth.TenantFromContext,request.id=github.com/ConorNevin/traceable/internal/tests/tenant.requestID"; DO NOT EDIT.
+//traceable:signature d36f00d253fdb417686e4fadf8cf5470
+
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingOrders is a Orders that records the calls made to it.
+type recordingOrders struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Orders = (*recordingOrders)(nil)
+
+func (r *recordingOrders) Cancel(a0 context.Context, a1 string) (r0 error) {
+	r.calls = append(r.calls, "Cancel")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingOrders) Count() (r0 int) {
+	r.calls = append(r.calls, "Count")
+	r.ctxs = append(r.ctxs, nil)
+	return
+}
+
+func (r *recordingOrders) Place(a0 context.Context, a1 string, a2 int) (r0 string, r1 error) {
+	r.calls = append(r.calls, "Place")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedOrders(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedOrders)
+	}{
+		{
+			method: "Cancel",
+			traced: true,
+			call: func(ctx context.Context, x *TracedOrders) {
+				x.Cancel(ctx, *new(string))
+			},
+		},
+		{
+			method: "Count",
+			traced: false,
+			call: func(ctx context.Context, x *TracedOrders) {
+				x.Count()
+			},
+		},
+		{
+			method: "Place",
+			traced: true,
+			call: func(ctx context.Context, x *TracedOrders) {
+				x.Place(ctx, *new(string), *new(int))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingOrders{}
+			tt.call(context.Background(), NewTracedOrders(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Orders." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
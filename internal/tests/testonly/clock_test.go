@@ -0,0 +1,12 @@
+package testonly
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is only used by the tests of the package.
+type Clock interface {
+	Now(ctx context.Context) time.Time
+	Sleep(ctx context.Context, d time.Duration)
+}
@@ -0,0 +1,50 @@
+// Code generated by "traceable -types Clock -tests -output clock_traced_test.go"; DO NOT EDIT.
+//traceable:signature da386e60e591cabf2689a1a46eb605fc
+
+package testonly
+
+import (
+	"context"
+	"time"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedClock is a traced implementation of Clock
+type TracedClock struct {
+	x Clock
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedClock returns a TracedClock that wraps x.
+func NewTracedClock(x Clock, opts ...runtime.Option) *TracedClock {
+	return &TracedClock{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedClock) Now(a0 context.Context) (r0 time.Time) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Now") {
+		return t.x.Now(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Clock.Now")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Now", nil)
+	return t.x.Now(a0)
+}
+
+func (t *TracedClock) Sleep(a0 context.Context, a1 time.Duration) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Sleep") {
+		t.x.Sleep(a0, a1)
+		return
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Clock.Sleep")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Sleep", nil)
+	t.x.Sleep(a0, a1)
+}
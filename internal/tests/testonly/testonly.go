@@ -0,0 +1,3 @@
+package testonly
+
+//go:generate ../../../bin/traceable -types Clock -tests -output clock_traced_test.go
@@ -0,0 +1,11 @@
+package hidden
+
+import (
+	"context"
+)
+
+// Hidden can only be implemented in this package.
+type Hidden interface {
+	Reveal(context.Context) error
+	hide()
+}
@@ -0,0 +1,48 @@
+// Code generated by "traceable -types Sealed -output sealed_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature a878a4ab7145656f8c02848f5b53ed15
+
+package unexported
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedSealed is a traced implementation of Sealed
+type TracedSealed struct {
+	x Sealed
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedSealed returns a TracedSealed that wraps x.
+func NewTracedSealed(x Sealed, opts ...runtime.Option) *TracedSealed {
+	return &TracedSealed{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedSealed) Open(a0 context.Context) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Open") {
+		return t.x.Open(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Sealed.Open")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Open", &r0)
+	return t.x.Open(a0)
+}
+
+func (t *TracedSealed) seal(a0 context.Context) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "seal") {
+		return t.x.seal(a0)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Sealed.seal")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "seal", &r0)
+	return t.x.seal(a0)
+}
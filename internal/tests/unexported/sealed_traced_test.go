@@ -0,0 +1,85 @@
+// Code generated by "traceable -types Sealed -output sealed_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature a878a4ab7145656f8c02848f5b53ed15
+
+package unexported
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingSealed is a Sealed that records the calls made to it.
+type recordingSealed struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Sealed = (*recordingSealed)(nil)
+
+func (r *recordingSealed) Open(a0 context.Context) (r0 error) {
+	r.calls = append(r.calls, "Open")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingSealed) seal(a0 context.Context) (r0 error) {
+	r.calls = append(r.calls, "seal")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func TestTracedSealed(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedSealed)
+	}{
+		{
+			method: "Open",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSealed) {
+				x.Open(ctx)
+			},
+		},
+		{
+			method: "seal",
+			traced: true,
+			call: func(ctx context.Context, x *TracedSealed) {
+				x.seal(ctx)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingSealed{}
+			tt.call(context.Background(), NewTracedSealed(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Sealed." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package unexported
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/internal/tests/unexported/hidden"
+)
+
+//go:generate ../../../bin/traceable -types Sealed -output sealed_traced.go -emit-tests
+
+// Sealed can only be implemented in this package, so it must be wrapped here.
+type Sealed interface {
+	Open(context.Context) error
+	seal(context.Context) error
+}
+
+// External embeds an interface with an unexported method of another package,
+// so it can not be wrapped anywhere but in that package.
+type External interface {
+	hidden.Hidden
+	Name() string
+}
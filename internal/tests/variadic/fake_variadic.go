@@ -0,0 +1,362 @@
+// Code generated by "traceable -types Variadic -fake -output fake_variadic.go"; DO NOT EDIT.
+//traceable:signature 490edbd3ab0288e7a60b4c9d083bee56
+
+package variadic
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeVariadic is a fake implementation of Variadic that records the calls made to it.
+type FakeVariadic struct {
+	mu sync.Mutex
+
+	// NoneFunc, if set, is called by None.
+	NoneFunc  func(context.Context, ...string)
+	callsNone []FakeVariadicNoneCall
+
+	// OneFunc, if set, is called by One.
+	OneFunc    func(context.Context, ...int) error
+	callsOne   []FakeVariadicOneCall
+	returnsOne struct {
+		r0 error
+	}
+
+	// OnlyVariadicFunc, if set, is called by OnlyVariadic.
+	OnlyVariadicFunc    func(...context.Context) bool
+	callsOnlyVariadic   []FakeVariadicOnlyVariadicCall
+	returnsOnlyVariadic struct {
+		r0 bool
+	}
+
+	// ThreeFunc, if set, is called by Three.
+	ThreeFunc    func(context.Context, ...[]byte) (string, int, error)
+	callsThree   []FakeVariadicThreeCall
+	returnsThree struct {
+		r0 string
+		r1 int
+		r2 error
+	}
+
+	// TwoFunc, if set, is called by Two.
+	TwoFunc    func(context.Context, string, ...interface{}) (int, error)
+	callsTwo   []FakeVariadicTwoCall
+	returnsTwo struct {
+		r0 int
+		r1 error
+	}
+
+	// UntracedFunc, if set, is called by Untraced.
+	UntracedFunc    func(string, ...string) (bool, error)
+	callsUntraced   []FakeVariadicUntracedCall
+	returnsUntraced struct {
+		r0 bool
+		r1 error
+	}
+}
+
+var _ Variadic = (*FakeVariadic)(nil)
+
+// FakeVariadicNoneCall holds the arguments of a call to FakeVariadic.None.
+type FakeVariadicNoneCall struct {
+	A0 context.Context
+	A1 []string
+}
+
+// FakeVariadicOneCall holds the arguments of a call to FakeVariadic.One.
+type FakeVariadicOneCall struct {
+	A0 context.Context
+	A1 []int
+}
+
+// FakeVariadicOnlyVariadicCall holds the arguments of a call to FakeVariadic.OnlyVariadic.
+type FakeVariadicOnlyVariadicCall struct {
+	A0 []context.Context
+}
+
+// FakeVariadicThreeCall holds the arguments of a call to FakeVariadic.Three.
+type FakeVariadicThreeCall struct {
+	A0 context.Context
+	A1 [][]byte
+}
+
+// FakeVariadicTwoCall holds the arguments of a call to FakeVariadic.Two.
+type FakeVariadicTwoCall struct {
+	A0 context.Context
+	A1 string
+	A2 []interface{}
+}
+
+// FakeVariadicUntracedCall holds the arguments of a call to FakeVariadic.Untraced.
+type FakeVariadicUntracedCall struct {
+	A0 string
+	A1 []string
+}
+
+// None implements Variadic.
+func (f *FakeVariadic) None(a0 context.Context, a1 ...string) {
+	f.mu.Lock()
+	f.callsNone = append(f.callsNone, FakeVariadicNoneCall{A0: a0, A1: a1})
+	fn := f.NoneFunc
+	f.mu.Unlock()
+
+	if fn != nil {
+		fn(a0, a1...)
+	}
+}
+
+// NoneCalls returns the arguments of each call made to None.
+func (f *FakeVariadic) NoneCalls() []FakeVariadicNoneCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeVariadicNoneCall(nil), f.callsNone...)
+}
+
+// NoneCallCount returns the number of calls made to None.
+func (f *FakeVariadic) NoneCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsNone)
+}
+
+// AssertNoneCallCount fails the test unless None was called want times.
+func (f *FakeVariadic) AssertNoneCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.NoneCallCount(); got != want {
+		t.Errorf("expected FakeVariadic.None to be called %d times, got %d", want, got)
+	}
+}
+
+// One implements Variadic.
+func (f *FakeVariadic) One(a0 context.Context, a1 ...int) error {
+	f.mu.Lock()
+	f.callsOne = append(f.callsOne, FakeVariadicOneCall{A0: a0, A1: a1})
+	fn := f.OneFunc
+	returns := f.returnsOne
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1...)
+	}
+	return returns.r0
+}
+
+// OneReturns sets the values returned by One when OneFunc is not set.
+func (f *FakeVariadic) OneReturns(r0 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsOne.r0 = r0
+}
+
+// OneCalls returns the arguments of each call made to One.
+func (f *FakeVariadic) OneCalls() []FakeVariadicOneCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeVariadicOneCall(nil), f.callsOne...)
+}
+
+// OneCallCount returns the number of calls made to One.
+func (f *FakeVariadic) OneCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsOne)
+}
+
+// AssertOneCallCount fails the test unless One was called want times.
+func (f *FakeVariadic) AssertOneCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.OneCallCount(); got != want {
+		t.Errorf("expected FakeVariadic.One to be called %d times, got %d", want, got)
+	}
+}
+
+// OnlyVariadic implements Variadic.
+func (f *FakeVariadic) OnlyVariadic(a0 ...context.Context) bool {
+	f.mu.Lock()
+	f.callsOnlyVariadic = append(f.callsOnlyVariadic, FakeVariadicOnlyVariadicCall{A0: a0})
+	fn := f.OnlyVariadicFunc
+	returns := f.returnsOnlyVariadic
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0...)
+	}
+	return returns.r0
+}
+
+// OnlyVariadicReturns sets the values returned by OnlyVariadic when OnlyVariadicFunc is not set.
+func (f *FakeVariadic) OnlyVariadicReturns(r0 bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsOnlyVariadic.r0 = r0
+}
+
+// OnlyVariadicCalls returns the arguments of each call made to OnlyVariadic.
+func (f *FakeVariadic) OnlyVariadicCalls() []FakeVariadicOnlyVariadicCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeVariadicOnlyVariadicCall(nil), f.callsOnlyVariadic...)
+}
+
+// OnlyVariadicCallCount returns the number of calls made to OnlyVariadic.
+func (f *FakeVariadic) OnlyVariadicCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsOnlyVariadic)
+}
+
+// AssertOnlyVariadicCallCount fails the test unless OnlyVariadic was called want times.
+func (f *FakeVariadic) AssertOnlyVariadicCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.OnlyVariadicCallCount(); got != want {
+		t.Errorf("expected FakeVariadic.OnlyVariadic to be called %d times, got %d", want, got)
+	}
+}
+
+// Three implements Variadic.
+func (f *FakeVariadic) Three(a0 context.Context, a1 ...[]byte) (string, int, error) {
+	f.mu.Lock()
+	f.callsThree = append(f.callsThree, FakeVariadicThreeCall{A0: a0, A1: a1})
+	fn := f.ThreeFunc
+	returns := f.returnsThree
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1...)
+	}
+	return returns.r0, returns.r1, returns.r2
+}
+
+// ThreeReturns sets the values returned by Three when ThreeFunc is not set.
+func (f *FakeVariadic) ThreeReturns(r0 string, r1 int, r2 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsThree.r0, f.returnsThree.r1, f.returnsThree.r2 = r0, r1, r2
+}
+
+// ThreeCalls returns the arguments of each call made to Three.
+func (f *FakeVariadic) ThreeCalls() []FakeVariadicThreeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeVariadicThreeCall(nil), f.callsThree...)
+}
+
+// ThreeCallCount returns the number of calls made to Three.
+func (f *FakeVariadic) ThreeCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsThree)
+}
+
+// AssertThreeCallCount fails the test unless Three was called want times.
+func (f *FakeVariadic) AssertThreeCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.ThreeCallCount(); got != want {
+		t.Errorf("expected FakeVariadic.Three to be called %d times, got %d", want, got)
+	}
+}
+
+// Two implements Variadic.
+func (f *FakeVariadic) Two(a0 context.Context, a1 string, a2 ...interface{}) (int, error) {
+	f.mu.Lock()
+	f.callsTwo = append(f.callsTwo, FakeVariadicTwoCall{A0: a0, A1: a1, A2: a2})
+	fn := f.TwoFunc
+	returns := f.returnsTwo
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1, a2...)
+	}
+	return returns.r0, returns.r1
+}
+
+// TwoReturns sets the values returned by Two when TwoFunc is not set.
+func (f *FakeVariadic) TwoReturns(r0 int, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsTwo.r0, f.returnsTwo.r1 = r0, r1
+}
+
+// TwoCalls returns the arguments of each call made to Two.
+func (f *FakeVariadic) TwoCalls() []FakeVariadicTwoCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeVariadicTwoCall(nil), f.callsTwo...)
+}
+
+// TwoCallCount returns the number of calls made to Two.
+func (f *FakeVariadic) TwoCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsTwo)
+}
+
+// AssertTwoCallCount fails the test unless Two was called want times.
+func (f *FakeVariadic) AssertTwoCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.TwoCallCount(); got != want {
+		t.Errorf("expected FakeVariadic.Two to be called %d times, got %d", want, got)
+	}
+}
+
+// Untraced implements Variadic.
+func (f *FakeVariadic) Untraced(a0 string, a1 ...string) (bool, error) {
+	f.mu.Lock()
+	f.callsUntraced = append(f.callsUntraced, FakeVariadicUntracedCall{A0: a0, A1: a1})
+	fn := f.UntracedFunc
+	returns := f.returnsUntraced
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(a0, a1...)
+	}
+	return returns.r0, returns.r1
+}
+
+// UntracedReturns sets the values returned by Untraced when UntracedFunc is not set.
+func (f *FakeVariadic) UntracedReturns(r0 bool, r1 error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.returnsUntraced.r0, f.returnsUntraced.r1 = r0, r1
+}
+
+// UntracedCalls returns the arguments of each call made to Untraced.
+func (f *FakeVariadic) UntracedCalls() []FakeVariadicUntracedCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeVariadicUntracedCall(nil), f.callsUntraced...)
+}
+
+// UntracedCallCount returns the number of calls made to Untraced.
+func (f *FakeVariadic) UntracedCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.callsUntraced)
+}
+
+// AssertUntracedCallCount fails the test unless Untraced was called want times.
+func (f *FakeVariadic) AssertUntracedCallCount(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}, want int) {
+	t.Helper()
+	if got := f.UntracedCallCount(); got != want {
+		t.Errorf("expected FakeVariadic.Untraced to be called %d times, got %d", want, got)
+	}
+}
@@ -0,0 +1,19 @@
+package variadic
+
+import (
+	"context"
+)
+
+//go:generate ../../../bin/traceable -types Variadic -output variadic_traced.go -emit-tests
+//go:generate ../../../bin/traceable -types Variadic -fake -output fake_variadic.go
+
+// Variadic has variadic methods with every number of results, as the
+// position of the variadic argument must not depend on them.
+type Variadic interface {
+	None(context.Context, ...string)
+	One(context.Context, ...int) error
+	Two(context.Context, string, ...interface{}) (int, error)
+	Three(context.Context, ...[]byte) (string, int, error)
+	OnlyVariadic(...context.Context) bool
+	Untraced(string, ...string) (bool, error)
+}
@@ -0,0 +1,75 @@
+// Code generated by "traceable -types Variadic -output variadic_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 33263a73401a34aab88108df80ef23e9
+
+package variadic
+
+import (
+	"context"
+
+	"github.com/ConorNevin/traceable/runtime"
+)
+
+// TracedVariadic is a traced implementation of Variadic
+type TracedVariadic struct {
+	x Variadic
+	o *runtime.Options
+
+	// ShouldTrace, if set, is called before each traced method with the
+	// method's context and name. When it returns false the call is passed
+	// straight through to the wrapped value without starting a span.
+	ShouldTrace func(ctx context.Context, method string) bool
+}
+
+// This is a compile-time assertion that the generated code is compatible
+// with the version of the traceable runtime package it is built with.
+const _ = runtime.SupportPackageIsVersion7
+
+// NewTracedVariadic returns a TracedVariadic that wraps x.
+func NewTracedVariadic(x Variadic, opts ...runtime.Option) *TracedVariadic {
+	return &TracedVariadic{x: x, o: runtime.NewOptions(opts...)}
+}
+
+func (t *TracedVariadic) None(a0 context.Context, a1 ...string) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "None") {
+		t.x.None(a0, a1...)
+		return
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Variadic.None")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "None", nil)
+	t.x.None(a0, a1...)
+}
+
+func (t *TracedVariadic) One(a0 context.Context, a1 ...int) (r0 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "One") {
+		return t.x.One(a0, a1...)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Variadic.One")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "One", &r0)
+	return t.x.One(a0, a1...)
+}
+
+func (t *TracedVariadic) OnlyVariadic(a0 ...context.Context) bool {
+	return t.x.OnlyVariadic(a0...)
+}
+
+func (t *TracedVariadic) Three(a0 context.Context, a1 ...[]byte) (r0 string, r1 int, r2 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Three") {
+		return t.x.Three(a0, a1...)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Variadic.Three")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Three", &r2)
+	return t.x.Three(a0, a1...)
+}
+
+func (t *TracedVariadic) Two(a0 context.Context, a1 string, a2 ...interface{}) (r0 int, r1 error) {
+	if t.ShouldTrace != nil && !t.ShouldTrace(a0, "Two") {
+		return t.x.Two(a0, a1, a2...)
+	}
+	span, a0 := runtime.StartSpan(a0, t.o, "Variadic.Two")
+	defer runtime.FinishSpanWithContext(a0, span, t.o, "Two", &r1)
+	return t.x.Two(a0, a1, a2...)
+}
+
+func (t *TracedVariadic) Untraced(a0 string, a1 ...string) (bool, error) {
+	return t.x.Untraced(a0, a1...)
+}
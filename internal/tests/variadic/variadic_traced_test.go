@@ -0,0 +1,137 @@
+// Code generated by "traceable -types Variadic -output variadic_traced.go -emit-tests"; DO NOT EDIT.
+//traceable:signature 33263a73401a34aab88108df80ef23e9
+
+package variadic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ConorNevin/traceable/runtime"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+// recordingVariadic is a Variadic that records the calls made to it.
+type recordingVariadic struct {
+	calls []string
+	ctxs  []context.Context
+}
+
+var _ Variadic = (*recordingVariadic)(nil)
+
+func (r *recordingVariadic) None(a0 context.Context, a1 ...string) {
+	r.calls = append(r.calls, "None")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingVariadic) One(a0 context.Context, a1 ...int) (r0 error) {
+	r.calls = append(r.calls, "One")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingVariadic) OnlyVariadic(a0 ...context.Context) (r0 bool) {
+	r.calls = append(r.calls, "OnlyVariadic")
+	r.ctxs = append(r.ctxs, nil)
+	return
+}
+
+func (r *recordingVariadic) Three(a0 context.Context, a1 ...[]byte) (r0 string, r1 int, r2 error) {
+	r.calls = append(r.calls, "Three")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingVariadic) Two(a0 context.Context, a1 string, a2 ...interface{}) (r0 int, r1 error) {
+	r.calls = append(r.calls, "Two")
+	r.ctxs = append(r.ctxs, a0)
+	return
+}
+
+func (r *recordingVariadic) Untraced(a0 string, a1 ...string) (r0 bool, r1 error) {
+	r.calls = append(r.calls, "Untraced")
+	r.ctxs = append(r.ctxs, nil)
+	return
+}
+
+func TestTracedVariadic(t *testing.T) {
+	tests := []struct {
+		method string
+		traced bool
+		call   func(ctx context.Context, x *TracedVariadic)
+	}{
+		{
+			method: "None",
+			traced: true,
+			call: func(ctx context.Context, x *TracedVariadic) {
+				x.None(ctx)
+			},
+		},
+		{
+			method: "One",
+			traced: true,
+			call: func(ctx context.Context, x *TracedVariadic) {
+				x.One(ctx)
+			},
+		},
+		{
+			method: "OnlyVariadic",
+			traced: false,
+			call: func(ctx context.Context, x *TracedVariadic) {
+				x.OnlyVariadic()
+			},
+		},
+		{
+			method: "Three",
+			traced: true,
+			call: func(ctx context.Context, x *TracedVariadic) {
+				x.Three(ctx)
+			},
+		},
+		{
+			method: "Two",
+			traced: true,
+			call: func(ctx context.Context, x *TracedVariadic) {
+				x.Two(ctx, *new(string))
+			},
+		},
+		{
+			method: "Untraced",
+			traced: false,
+			call: func(ctx context.Context, x *TracedVariadic) {
+				x.Untraced(*new(string))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tracer := mocktracer.New()
+			x := &recordingVariadic{}
+			tt.call(context.Background(), NewTracedVariadic(x, runtime.WithTracer(tracer)))
+
+			if len(x.calls) != 1 || x.calls[0] != tt.method {
+				t.Fatalf("expected a single call to %s, got %v", tt.method, x.calls)
+			}
+
+			spans := tracer.FinishedSpans()
+			if !tt.traced {
+				if len(spans) != 0 {
+					t.Fatalf("expected no spans, got %d", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("expected a single span, got %d", len(spans))
+			}
+			if want := "Variadic." + tt.method; spans[0].OperationName != want {
+				t.Errorf("expected span %q, got %q", want, spans[0].OperationName)
+			}
+			if opentracing.SpanFromContext(x.ctxs[0]) != spans[0] {
+				t.Errorf("expected the span to be passed to %s", tt.method)
+			}
+		})
+	}
+}
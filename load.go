@@ -0,0 +1,111 @@
+package traceable
+
+import (
+	"go/build"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// LoadOptions control how the packages that code is generated from are
+// loaded.
+type LoadOptions struct {
+	// Tags are additional build tags to consider satisfied, so that
+	// interfaces declared in files with build constraints can be generated.
+	Tags []string
+	// Mod is the -mod flag passed to the go command, e.g. "mod" or "vendor".
+	Mod string
+	// GOOS and GOARCH are the platform to load the packages for. The current
+	// platform is used if they are empty.
+	GOOS, GOARCH string
+	// Tests loads the packages along with their _test.go files, so that
+	// interfaces declared in tests can be generated.
+	Tests bool
+}
+
+// config returns the configuration for loading packages with mode.
+func (o LoadOptions) config(mode packages.LoadMode) *packages.Config {
+	cfg := &packages.Config{
+		Mode:      mode,
+		ParseFile: parseFile,
+		Tests:     o.Tests,
+	}
+	if len(o.Tags) > 0 {
+		cfg.BuildFlags = append(cfg.BuildFlags, "-tags="+strings.Join(o.Tags, ","))
+	}
+	if o.Mod != "" {
+		cfg.BuildFlags = append(cfg.BuildFlags, "-mod="+o.Mod)
+	}
+	if o.GOOS != "" || o.GOARCH != "" {
+		cfg.Env = os.Environ()
+		if o.GOOS != "" {
+			cfg.Env = append(cfg.Env, "GOOS="+o.GOOS)
+		}
+		if o.GOARCH != "" {
+			cfg.Env = append(cfg.Env, "GOARCH="+o.GOARCH)
+		}
+	}
+
+	return cfg
+}
+
+// matchFile reports whether the file fi in dir is one of the source files
+// loaded for its package.
+func (o LoadOptions) matchFile(dir string, fi os.FileInfo) bool {
+	if fi.IsDir() || !strings.HasSuffix(fi.Name(), ".go") {
+		return false
+	}
+	if !o.Tests && strings.HasSuffix(fi.Name(), "_test.go") {
+		return false
+	}
+
+	ctxt := o.buildContext()
+	ok, err := ctxt.MatchFile(dir, fi.Name())
+	return err == nil && ok
+}
+
+// buildContext returns the context that selects the files of the packages
+// loaded with o.
+func (o LoadOptions) buildContext() build.Context {
+	ctxt := build.Default
+	ctxt.BuildTags = append(append([]string(nil), ctxt.BuildTags...), o.Tags...)
+	if o.GOOS != "" {
+		ctxt.GOOS = o.GOOS
+	}
+	if o.GOARCH != "" {
+		ctxt.GOARCH = o.GOARCH
+	}
+
+	return ctxt
+}
+
+// withTestVariants returns pkgs, as loaded with Tests set, with each package
+// that has a test variant replaced by that variant, which also includes its
+// _test.go files, and without the generated test binaries.
+func withTestVariants(pkgs []*packages.Package) []*packages.Package {
+	tested := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if isTestVariant(pkg) {
+			tested[pkg.PkgPath] = true
+		}
+	}
+
+	var selected []*packages.Package
+	for _, pkg := range pkgs {
+		switch {
+		case isTestVariant(pkg):
+		case tested[pkg.PkgPath], strings.HasSuffix(pkg.PkgPath, ".test"):
+			continue
+		}
+		selected = append(selected, pkg)
+	}
+
+	return selected
+}
+
+// isTestVariant reports whether pkg is a package compiled for its tests,
+// whose ID is e.g. "example.com/x [example.com/x.test]".
+func isTestVariant(pkg *packages.Package) bool {
+	return strings.Contains(pkg.ID, " [")
+}
@@ -0,0 +1,158 @@
+package traceable
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestLoadOptions_config(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       LoadOptions
+		buildFlags []string
+		env        []string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:       "tags and mod",
+			opts:       LoadOptions{Tags: []string{"integration", "e2e"}, Mod: "vendor"},
+			buildFlags: []string{"-tags=integration,e2e", "-mod=vendor"},
+		},
+		{
+			name: "platform",
+			opts: LoadOptions{GOOS: "windows", GOARCH: "arm64"},
+			env:  []string{"GOOS=windows", "GOARCH=arm64"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.opts.config(loadMode)
+			qt.Check(t, cfg.Mode, qt.Equals, loadMode)
+			qt.Check(t, cfg.ParseFile, qt.Not(qt.IsNil))
+			qt.Check(t, cfg.BuildFlags, qt.DeepEquals, tt.buildFlags)
+			if tt.env == nil {
+				qt.Check(t, cfg.Env, qt.IsNil)
+				return
+			}
+			// The platform is appended to the environment so that it takes
+			// precedence.
+			qt.Check(t, cfg.Env[len(cfg.Env)-len(tt.env):], qt.DeepEquals, tt.env)
+		})
+	}
+}
+
+func TestLoadOptions_matchFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"store.go":         "package store\n",
+		"store_test.go":    "package store\n",
+		"integration.go":   "//go:build integration\n\npackage store\n",
+		"store_windows.go": "package store\n",
+		"README.md":        "# store\n",
+	}
+	for name, src := range files {
+		qt.Assert(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0644), qt.IsNil)
+	}
+
+	tests := []struct {
+		name string
+		opts LoadOptions
+		want []string
+	}{
+		{
+			name: "default",
+			opts: LoadOptions{GOOS: "linux"},
+			want: []string{"store.go"},
+		},
+		{
+			name: "tags",
+			opts: LoadOptions{GOOS: "linux", Tags: []string{"integration"}},
+			want: []string{"integration.go", "store.go"},
+		},
+		{
+			name: "goos",
+			opts: LoadOptions{GOOS: "windows"},
+			want: []string{"store.go", "store_windows.go"},
+		},
+		{
+			name: "tests",
+			opts: LoadOptions{GOOS: "linux", Tests: true},
+			want: []string{"store.go", "store_test.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			infos, err := ioutil.ReadDir(dir)
+			qt.Assert(t, err, qt.IsNil)
+
+			var got []string
+			for _, fi := range infos {
+				if tt.opts.matchFile(dir, fi) {
+					got = append(got, fi.Name())
+				}
+			}
+			qt.Check(t, got, qt.DeepEquals, tt.want)
+		})
+	}
+}
+
+func Test_withTestVariants(t *testing.T) {
+	pkg := func(id, path string) *packages.Package {
+		return &packages.Package{ID: id, PkgPath: path}
+	}
+	pkgs := []*packages.Package{
+		pkg("example.com/a", "example.com/a"),
+		pkg("example.com/b", "example.com/b"),
+		pkg("example.com/a [example.com/a.test]", "example.com/a"),
+		pkg("example.com/a_test [example.com/a.test]", "example.com/a_test"),
+		pkg("example.com/a.test", "example.com/a.test"),
+	}
+
+	var got []string
+	for _, p := range withTestVariants(pkgs) {
+		got = append(got, p.ID)
+	}
+	qt.Check(t, got, qt.DeepEquals, []string{
+		"example.com/b",
+		"example.com/a [example.com/a.test]",
+		"example.com/a_test [example.com/a.test]",
+	})
+}
+
+func TestGenerator_ParsePackage_tests(t *testing.T) {
+	c := qt.New(t)
+
+	path := modulePath + "/" + fixturesDir + "/testonly"
+	load := func(opts LoadOptions) *Interface {
+		g := &Generator{RootPackage: path, LoadOptions: opts}
+		c.Assert(g.ParsePackage([]string{path}), qt.IsNil)
+		i, err := g.pkgs[path].lookupInterface("Clock")
+		c.Assert(err, qt.IsNil)
+		return i
+	}
+
+	c.Check(load(LoadOptions{}), qt.IsNil)
+	c.Check(load(LoadOptions{Tests: true}), qt.Not(qt.IsNil))
+}
+
+func TestGenerator_ParsePackage_tags(t *testing.T) {
+	c := qt.New(t)
+
+	path := modulePath + "/" + fixturesDir + "/buildtags"
+	g := &Generator{RootPackage: path, LoadOptions: LoadOptions{Tags: []string{"integration"}}}
+	c.Assert(g.ParsePackage([]string{path}), qt.IsNil)
+	i, err := g.pkgs[path].lookupInterface("Fixtures")
+	c.Assert(err, qt.IsNil)
+	c.Assert(i, qt.Not(qt.IsNil))
+	c.Check(i.build.String(), qt.Equals, "integration")
+}
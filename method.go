@@ -1,15 +1,48 @@
 package traceable
 
 import (
+	"go/token"
 	"go/types"
 	"strconv"
 )
 
 type Method struct {
-	name       string
+	name string
+	// pkg is the import path of the package that declares the method.
+	pkg string
+	// embeddedFrom is the embedded interface the method was promoted from,
+	// if it is not declared by the interface itself.
+	embeddedFrom string
+
 	args       []types.Type
 	returns    []types.Type
 	isVariadic bool
+
+	// skip is set when the method is annotated with //traceable:skip.
+	skip bool
+	// async is set when the method is annotated with //traceable:async, as
+	// it starts work that outlives the call.
+	async bool
+
+	// argNames are the names of the parameters as declared.
+	argNames []string
+	// returnNames are the names of the results as declared.
+	returnNames []string
+	// sensitive is set for the parameters annotated with
+	// //traceable:sensitive.
+	sensitive []bool
+	// tags are the arguments recorded as tags on the method's span.
+	tags []argTag
+	// baggage are the arguments set as baggage items of the method's span.
+	baggage []argTag
+	// carriers are the arguments the method's span is propagated in.
+	carriers []carrier
+}
+
+// reachableFrom reports whether the method can be implemented by a type in
+// the package with the import path pkg.
+func (m Method) reachableFrom(pkg string) bool {
+	return token.IsExported(m.name) || m.pkg == pkg
 }
 
 func (m Method) acceptsContext() bool {
@@ -36,6 +69,21 @@ func (m Method) contextArg() string {
 	return ""
 }
 
+// errorResult returns the index of the result that holds the error returned
+// by the method, or -1 if the method does not return an error.
+func (m Method) errorResult() int {
+	if len(m.returns) == 0 {
+		return -1
+	}
+
+	last := len(m.returns) - 1
+	if !types.Identical(m.returns[last], types.Universe.Lookup("error").Type()) {
+		return -1
+	}
+
+	return last
+}
+
 func (m Method) imports() map[string]struct{} {
 	imports := make(map[string]struct{})
 	for _, t := range m.args {
@@ -98,6 +98,54 @@ func Test_Method_contextArg(t *testing.T) {
 	}
 }
 
+func Test_Method_errorResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		method Method
+		want   int
+	}{
+		{
+			name:   "no results",
+			method: Method{},
+			want:   -1,
+		},
+		{
+			name: "does not return an error",
+			method: Method{
+				returns: []types.Type{types.Typ[types.Float64]},
+			},
+			want: -1,
+		},
+		{
+			name: "returns only an error",
+			method: Method{
+				returns: []types.Type{newErrorType()},
+			},
+			want: 0,
+		},
+		{
+			name: "returns a value and an error",
+			method: Method{
+				returns: []types.Type{types.Typ[types.Float64], newErrorType()},
+			},
+			want: 1,
+		},
+		{
+			name: "error is not the last result",
+			method: Method{
+				returns: []types.Type{newErrorType(), types.Typ[types.Float64]},
+			},
+			want: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qt.Check(t, tt.method.errorResult(), qt.Equals, tt.want)
+		})
+	}
+}
+
 func Test_Method_imports(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -0,0 +1,100 @@
+package traceable
+
+import (
+	"go/build/constraint"
+	"log"
+	"strings"
+)
+
+// noTraceTag is the build tag that selects the pass-through wrappers
+// generated by GenerateNoTrace over the traced ones.
+const noTraceTag = "notrace"
+
+// GenerateAllNoTrace generates pass-through wrappers for each of the types.
+func (g *Generator) GenerateAllNoTrace(types []string) error {
+	for _, t := range types {
+		if err := g.GenerateNoTrace(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GenerateNoTrace generates TracedX for the interface typeName as a
+// pass-through wrapper that is only built with the notrace build tag. It has
+// the same constructor and fields as the traced wrapper, so that binaries
+// built with the tag drop tracing without changing the code using it.
+func (g *Generator) GenerateNoTrace(typeName string) error {
+	log.Printf("generating pass-through wrapper for %s", typeName)
+
+	if err := g.loadInterface(typeName); err != nil {
+		return err
+	}
+	g.sortMethods()
+
+	g.printHeader(typeName, g.fileConstraint(true))
+	g.printImports(contextPackagePath, optionPackagePath)
+	g.printNoTraceStruct(typeName)
+	g.printNoTraceMethods(typeName)
+
+	return nil
+}
+
+// fileConstraint returns the build constraint of a file generated for the
+// Interface. The file refers to the interface, so it is built under the same
+// constraint as the file declaring it. With NoTrace, it is also built with
+// the notrace tag if notrace is set and without it otherwise.
+func (g *Generator) fileConstraint(notrace bool) constraint.Expr {
+	x := g.Interface.build
+	if !g.NoTrace {
+		return x
+	}
+
+	var tag constraint.Expr = &constraint.TagExpr{Tag: noTraceTag}
+	if !notrace {
+		tag = &constraint.NotExpr{X: tag}
+	}
+	if x == nil {
+		return tag
+	}
+
+	return &constraint.AndExpr{X: x, Y: tag}
+}
+
+func (g *Generator) printNoTraceStruct(typeName string) {
+	structName := getStructName(typeName)
+	interfaceName := g.interfaceName(typeName)
+	g.Printf("// Traced%s is an implementation of %s that calls the wrapped value\n", structName, typeName)
+	g.Printf("// directly, as tracing is compiled out by the %s build tag.\n", noTraceTag)
+	g.Printf("type Traced%s struct {\n", structName)
+	g.Printf("\tx %s\n", interfaceName)
+	g.Printf("\n")
+	g.Printf("// ShouldTrace is never called. It is declared so that code setting it\n")
+	g.Printf("// builds with and without the %s build tag.\n", noTraceTag)
+	g.Printf("ShouldTrace func(ctx %s.Context, method string) bool\n", g.importName(contextPackagePath))
+	g.Printf("}")
+	g.Printf("\n")
+	g.Printf("\n")
+	g.Printf("// NewTraced%[1]s returns a Traced%[1]s that wraps x. The options are\n", structName)
+	g.Printf("// ignored.\n")
+	g.Printf("func NewTraced%[1]s(x %[2]s, _ ...%[3]s.Option) *Traced%[1]s {\n", structName, interfaceName, g.importName(optionPackagePath))
+	g.Printf("return &Traced%s{x: x}\n", structName)
+	g.Printf("}\n")
+	g.Printf("\n")
+}
+
+func (g *Generator) printNoTraceMethods(typeName string) {
+	structName := getStructName(typeName)
+
+	for i, m := range g.Interface.methods {
+		argList, argNames := g.params(m)
+
+		g.Printf("func (t *Traced%s) %s(%s) %s {\n", structName, m.name, strings.Join(argList, ","), g.results(m, false))
+		g.printDelegate(m, argNames)
+		g.Printf("}\n")
+		if i != len(g.Interface.methods)-1 {
+			g.Printf("\n")
+		}
+	}
+}
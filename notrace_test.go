@@ -0,0 +1,64 @@
+package traceable
+
+import (
+	"go/build/constraint"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestGenerator_fileConstraint(t *testing.T) {
+	linux, err := constraint.Parse("//go:build linux")
+	qt.Assert(t, err, qt.IsNil)
+
+	tests := []struct {
+		name    string
+		build   constraint.Expr
+		noTrace bool
+		notrace bool
+		want    string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:  "interface",
+			build: linux,
+			want:  "linux",
+		},
+		{
+			name:    "traced",
+			noTrace: true,
+			want:    "!notrace",
+		},
+		{
+			name:    "pass-through",
+			noTrace: true,
+			notrace: true,
+			want:    "notrace",
+		},
+		{
+			name:    "interface and pass-through",
+			build:   linux,
+			noTrace: true,
+			notrace: true,
+			want:    "linux && notrace",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{NoTrace: tt.noTrace}
+			g.Interface.build = tt.build
+
+			x := g.fileConstraint(tt.notrace)
+			if tt.want == "" {
+				qt.Check(t, x, qt.IsNil)
+				return
+			}
+			qt.Assert(t, x, qt.Not(qt.IsNil))
+			qt.Check(t, x.String(), qt.Equals, tt.want)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package traceable
+
+import (
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Packages are parsed packages that can be shared by several Generators, so
+// that packages needed by many of them are only loaded once.
+type Packages struct {
+	pkgs       map[string]*Package
+	packageMap map[string]string
+	dirs       map[string]string
+}
+
+// LoadPackages loads and parses the packages matching patterns, which are
+// relative to dir, with a single call to packages.Load.
+func LoadPackages(dir string, patterns []string, opts LoadOptions) (*Packages, error) {
+	cfg := opts.config(loadMode | packages.NeedFiles)
+	cfg.Dir = dir
+	loaded, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var g Generator
+	p := &Packages{dirs: make(map[string]string)}
+	for _, pkg := range withTestVariants(loaded) {
+		if err := g.addPackage(pkg); err != nil {
+			return nil, err
+		}
+		if len(pkg.GoFiles) > 0 {
+			p.dirs[filepath.Dir(pkg.GoFiles[0])] = pkg.PkgPath
+		}
+	}
+	p.pkgs, p.packageMap = g.pkgs, g.packageMap
+
+	return p, nil
+}
+
+// PackagePath returns the import path of the loaded package in dir, which
+// must be absolute.
+func (p *Packages) PackagePath(dir string) (string, bool) {
+	path, ok := p.dirs[filepath.Clean(dir)]
+	return path, ok
+}
+
+// UsePackages makes g generate code from the packages in p instead of those
+// loaded by ParsePackage. Generators sharing p can be used concurrently.
+func (g *Generator) UsePackages(p *Packages) {
+	g.pkgs = p.pkgs
+	g.packageMap = p.packageMap
+}
@@ -0,0 +1,63 @@
+package traceable
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+const (
+	modulePath  = "github.com/ConorNevin/traceable"
+	fixturesDir = "internal/tests"
+)
+
+func TestLoadPackages(t *testing.T) {
+	dirs := []string{"geometry", "searcher"}
+
+	patterns := make([]string, len(dirs))
+	for i, dir := range dirs {
+		patterns[i] = "./" + dir
+	}
+	pkgs, err := LoadPackages(fixturesDir, patterns, LoadOptions{})
+	qt.Assert(t, err, qt.IsNil)
+
+	// Generators sharing the packages generate the same output as the
+	// go:generate directives of the fixtures, even when run concurrently.
+	var wg sync.WaitGroup
+	got := make([][]byte, len(dirs))
+	for i, dir := range dirs {
+		abs, err := filepath.Abs(filepath.Join(fixturesDir, dir))
+		qt.Assert(t, err, qt.IsNil)
+		path, ok := pkgs.PackagePath(abs)
+		qt.Assert(t, ok, qt.IsTrue)
+		qt.Assert(t, path, qt.Equals, modulePath+"/"+fixturesDir+"/"+dir)
+
+		typeName := strings.Title(dir)
+		g := &Generator{
+			RootPackage:       path,
+			OutputPackagePath: path,
+			Args:              []string{"-types", typeName, "-output", dir + "_traced.go", "-emit-tests"},
+		}
+		g.UsePackages(pkgs)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := g.Generate(typeName); err != nil {
+				t.Error(err)
+			}
+			got[i] = g.Format()
+		}(i)
+	}
+	wg.Wait()
+
+	for i, dir := range dirs {
+		want, err := ioutil.ReadFile(filepath.Join(fixturesDir, dir, dir+"_traced.go"))
+		qt.Assert(t, err, qt.IsNil)
+		qt.Check(t, string(got[i]), qt.Equals, string(want))
+	}
+}
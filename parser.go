@@ -2,58 +2,214 @@ package traceable
 
 import (
 	"go/ast"
+	"go/build/constraint"
+	goparser "go/parser"
+	"go/token"
 	"go/types"
-	"log"
+	"path/filepath"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
 )
 
+// loadMode is what is loaded for the packages that code is generated from.
+// Only their types and syntax are needed, so their dependencies are loaded
+// from export data and no type-checking results are kept.
+const loadMode = packages.NeedName | packages.NeedTypes | packages.NeedSyntax
+
+// parseFile parses a Go source file for packages.Load without function
+// bodies, which are never needed to generate code but are the bulk of the
+// work of parsing and type-checking a package.
+func parseFile(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+	f, err := goparser.ParseFile(fset, filename, src, goparser.ParseComments)
+	if f != nil {
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				fd.Body = nil
+			}
+		}
+	}
+
+	return f, err
+}
+
 type parser struct {
 	imports            map[string]ImportedPackage
 	importedInterfaces map[string]map[string]*ast.InterfaceType
 
 	otherInterfaces map[string]map[string]*ast.InterfaceType
+
+	// methodDirectives maps the position of an interface method's name to the
+	// directives found in its doc comment.
+	methodDirectives map[token.Pos]directives
+	// paramDirectives maps the position of a method parameter's name to the
+	// directives in the comment at the end of its line.
+	paramDirectives map[token.Pos]directives
+	// fieldDirectives maps the position of a struct field's name to the
+	// directives found in its doc comment.
+	fieldDirectives map[token.Pos]directives
+	// typeDirectives maps the position of a declared type's name to the
+	// directives found in its doc comment.
+	typeDirectives map[token.Pos]directives
+
+	// files are the parsed files of the package, in fset.
+	files []*ast.File
+	fset  *token.FileSet
 }
 
+// parsePackage returns the Package for pkg. Its interfaces are only parsed
+// when they are looked up.
 func (p *parser) parsePackage(pkg *packages.Package) (*Package, error) {
-	var interfaces []*Interface
+	p.methodDirectives = methodDirectivesOf(pkg.Syntax)
+	p.paramDirectives = paramDirectivesOf(pkg.Fset, pkg.Syntax)
+	p.fieldDirectives = fieldDirectivesOf(pkg.Syntax)
+	p.typeDirectives = typeDirectivesOf(pkg.Syntax)
+	p.files = pkg.Syntax
+	p.fset = pkg.Fset
 
-	scope := pkg.Types.Scope()
-	for _, name := range scope.Names() {
-		o := scope.Lookup(name)
-		if o == nil {
-			continue
+	return &Package{
+		name:       pkg.Name,
+		importPath: pkg.PkgPath,
+		imports:    pkg.Types.Imports(),
+		aliases:    importAliases(pkg.Syntax),
+		syntax:     pkg.Syntax,
+		fset:       pkg.Fset,
+		types:      pkg.Types,
+		parser:     p,
+	}, nil
+}
+
+// parseNamed parses the interface type declared as name in pkg, returning
+// nil if there is no such interface.
+func (p *parser) parseNamed(pkg *types.Package, name string) (*Interface, error) {
+	o, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, nil
+	}
+	ti, ok := o.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, nil
+	}
+
+	i, err := p.parseInterface(o.Name(), pkg.Path(), ti)
+	if err != nil {
+		return nil, err
+	}
+	i.generic = hasTypeParams(o.Type())
+	for _, f := range p.files {
+		if f.Pos() <= o.Pos() && o.Pos() < f.End() {
+			i.build = buildConstraint(p.fset.File(f.Pos()).Name(), f)
 		}
+	}
+	if gd, ts := typeSpecAt(p.files, o.Pos()); ts != nil {
+		i.tags, err = parseInterfaceTags(name, parseDirectives(gd.Doc, ts.Doc, ts.Comment))
+		if err != nil {
+			return nil, err
+		}
+	}
 
-		switch ti := o.Type().Underlying().(type) {
-		case *types.Interface:
-			log.Printf("found interface: %s\n", o.Name())
-			i, err := p.parseInterface(o.Name(), pkg.PkgPath, ti)
+	return i, nil
+}
+
+// buildConstraint returns the build constraint of f, the file name: that of
+// its //go:build line or else its // +build lines, along with the GOOS and
+// GOARCH its name is constrained to, e.g. by a _linux.go suffix. It returns
+// nil if the file has no constraint.
+func buildConstraint(name string, f *ast.File) constraint.Expr {
+	x := commentConstraint(f)
+	if y := fileNameConstraint(name); y != nil {
+		if x == nil {
+			return y
+		}
+		x = &constraint.AndExpr{X: y, Y: x}
+	}
+
+	return x
+}
+
+// commentConstraint returns the build constraint of f from its //go:build
+// line or else its // +build lines, or nil if it has none.
+func commentConstraint(f *ast.File) constraint.Expr {
+	var plus constraint.Expr
+	for _, g := range f.Comments {
+		if g.Pos() >= f.Package {
+			break
+		}
+		for _, c := range g.List {
+			x, err := constraint.Parse(c.Text)
 			if err != nil {
-				return nil, err
+				continue
+			}
+			if constraint.IsGoBuild(c.Text) {
+				return x
+			}
+			if plus == nil {
+				plus = x
+			} else {
+				plus = &constraint.AndExpr{X: plus, Y: x}
 			}
-
-			interfaces = append(interfaces, i)
-		default:
-			log.Printf("skipping %s", name)
 		}
 	}
 
-	return &Package{
-		name:       pkg.Name,
-		importPath: pkg.PkgPath,
-		imports:    pkg.Types.Imports(),
-		interfaces: interfaces,
-	}, nil
+	return plus
 }
 
+// fileNameConstraint returns the build constraint implied by the name of a
+// Go source file, like the go command: name_GOOS, name_GOARCH and
+// name_GOOS_GOARCH, optionally followed by _test, are only built for that
+// GOOS and GOARCH. It returns nil if the name implies no constraint.
+func fileNameConstraint(name string) constraint.Expr {
+	name = strings.TrimSuffix(filepath.Base(name), ".go")
+	idx := strings.IndexByte(name, '_')
+	if idx == -1 {
+		return nil
+	}
+	// The part before the first _ is never a constraint, e.g. linux.go.
+	l := strings.Split(strings.TrimSuffix(name[idx:], "_test"), "_")
+
+	n := len(l)
+	switch {
+	case n >= 2 && knownOS[l[n-2]] && knownArch[l[n-1]]:
+		return &constraint.AndExpr{X: &constraint.TagExpr{Tag: l[n-2]}, Y: &constraint.TagExpr{Tag: l[n-1]}}
+	case knownOS[l[n-1]], knownArch[l[n-1]]:
+		return &constraint.TagExpr{Tag: l[n-1]}
+	}
+
+	return nil
+}
+
+// knownOS and knownArch are the GOOS and GOARCH values recognised in file
+// names, as listed by go/build.
+var (
+	knownOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true,
+		"freebsd": true, "hurd": true, "illumos": true, "ios": true,
+		"js": true, "linux": true, "nacl": true, "netbsd": true,
+		"openbsd": true, "plan9": true, "solaris": true, "wasip1": true,
+		"windows": true, "zos": true,
+	}
+	knownArch = map[string]bool{
+		"386": true, "amd64": true, "amd64p32": true, "arm": true,
+		"armbe": true, "arm64": true, "arm64be": true, "loong64": true,
+		"mips": true, "mipsle": true, "mips64": true, "mips64le": true,
+		"mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true,
+		"ppc64le": true, "riscv": true, "riscv64": true, "s390": true,
+		"s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+	}
+)
+
 func (p *parser) parseInterface(name, pkg string, ti *types.Interface) (*Interface, error) {
-	i := Interface{name: name, methods: make([]Method, ti.NumMethods())}
+	i := Interface{
+		name:       name,
+		methods:    make([]Method, ti.NumMethods()),
+		constraint: isConstraint(ti),
+	}
 	for idx := 0; idx < ti.NumMethods(); idx++ {
 		m, err := p.parseFunc(ti.Method(idx))
 		if err != nil {
 			return nil, err
 		}
+		m.embeddedFrom = embeddedFrom(ti, ti.Method(idx))
 
 		i.methods[idx] = *m
 	}
@@ -61,21 +217,192 @@ func (p *parser) parseInterface(name, pkg string, ti *types.Interface) (*Interfa
 	return &i, nil
 }
 
+// embeddedFrom returns the name of the type embedded in ti that f is promoted
+// from, or "" if f is declared by ti itself.
+func embeddedFrom(ti *types.Interface, f *types.Func) string {
+	for idx := 0; idx < ti.NumExplicitMethods(); idx++ {
+		if ti.ExplicitMethod(idx) == f {
+			return ""
+		}
+	}
+
+	for idx := 0; idx < ti.NumEmbeddeds(); idx++ {
+		e := ti.EmbeddedType(idx)
+		ei, ok := e.Underlying().(*types.Interface)
+		if !ok {
+			continue
+		}
+
+		for j := 0; j < ei.NumMethods(); j++ {
+			if ei.Method(j) == f {
+				return types.TypeString(e, nil)
+			}
+		}
+	}
+
+	return ""
+}
+
 func (p *parser) parseFunc(f *types.Func) (*Method, error) {
 	sig := f.Type().(*types.Signature)
 	m := &Method{
-		name:       f.Name(),
-		args:       make([]types.Type, sig.Params().Len()),
-		returns:    make([]types.Type, sig.Results().Len()),
-		isVariadic: sig.Variadic(),
+		name:        f.Name(),
+		args:        make([]types.Type, sig.Params().Len()),
+		returns:     make([]types.Type, sig.Results().Len()),
+		isVariadic:  sig.Variadic(),
+		skip:        p.methodDirectives[f.Pos()].has("skip"),
+		async:       p.methodDirectives[f.Pos()].has("async"),
+		argNames:    make([]string, sig.Params().Len()),
+		returnNames: make([]string, sig.Results().Len()),
+		sensitive:   make([]bool, sig.Params().Len()),
+	}
+
+	if f.Pkg() != nil {
+		m.pkg = f.Pkg().Path()
 	}
 
+	d := p.methodDirectives[f.Pos()]
+	sensitive := make(map[string]bool)
+	for _, name := range strings.Split(d[sensitiveDirective], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			sensitive[name] = true
+		}
+	}
 	for i := range m.args {
-		m.args[i] = sig.Params().At(i).Type()
+		param := sig.Params().At(i)
+		m.args[i] = param.Type()
+		m.argNames[i] = param.Name()
+		m.sensitive[i] = sensitive[param.Name()] || p.paramDirectives[param.Pos()].has(sensitiveDirective)
 	}
 	for i := range m.returns {
 		m.returns[i] = sig.Results().At(i).Type()
+		m.returnNames[i] = sig.Results().At(i).Name()
 	}
+	var err error
+	if value, ok := d[tagDirective]; ok {
+		if m.tags, err = p.parseArgTags(m, f.Pkg(), sig.Params(), tagDirective, value); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.parseStructTags(m, sig.Params()); err != nil {
+		return nil, err
+	}
+	if value, ok := d[baggageDirective]; ok {
+		if m.baggage, err = p.parseArgTags(m, f.Pkg(), sig.Params(), baggageDirective, value); err != nil {
+			return nil, err
+		}
+	}
+	p.parseCarriers(m)
 
 	return m, nil
 }
+
+// methodDirectivesOf collects the directives of every interface method
+// declared in files.
+func methodDirectivesOf(files []*ast.File) map[token.Pos]directives {
+	found := make(map[token.Pos]directives)
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			it, ok := n.(*ast.InterfaceType)
+			if !ok {
+				return true
+			}
+
+			for _, field := range it.Methods.List {
+				if len(field.Names) == 0 {
+					// embedded interface
+					continue
+				}
+				found[field.Names[0].Pos()] = parseDirectives(field.Doc, field.Comment)
+			}
+			return true
+		})
+	}
+
+	return found
+}
+
+// paramDirectivesOf collects the directives in the comments at the end of the
+// lines declaring the parameters of interface methods in files, e.g.
+//
+//	Login(ctx context.Context,
+//		password string, //traceable:sensitive
+//	) error
+func paramDirectivesOf(fset *token.FileSet, files []*ast.File) map[token.Pos]directives {
+	found := make(map[token.Pos]directives)
+	for _, f := range files {
+		// The comments that end a line, by line.
+		trailing := make(map[int]*ast.CommentGroup)
+		for _, g := range f.Comments {
+			trailing[fset.Position(g.Pos()).Line] = g
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			it, ok := n.(*ast.InterfaceType)
+			if !ok {
+				return true
+			}
+
+			for _, method := range it.Methods.List {
+				ft, ok := method.Type.(*ast.FuncType)
+				if !ok || len(method.Names) == 0 {
+					continue
+				}
+				for _, param := range ft.Params.List {
+					for _, name := range param.Names {
+						g, ok := trailing[fset.Position(name.Pos()).Line]
+						if ok && g.Pos() > name.End() && g.Pos() < ft.Params.Closing {
+							found[name.Pos()] = parseDirectives(g)
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	return found
+}
+
+// fieldDirectivesOf collects the directives of every struct field declared in
+// files.
+func fieldDirectivesOf(files []*ast.File) map[token.Pos]directives {
+	found := make(map[token.Pos]directives)
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			st, ok := n.(*ast.StructType)
+			if !ok {
+				return true
+			}
+
+			for _, field := range st.Fields.List {
+				d := parseDirectives(field.Doc, field.Comment)
+				for _, name := range field.Names {
+					found[name.Pos()] = d
+				}
+			}
+			return true
+		})
+	}
+
+	return found
+}
+
+// typeDirectivesOf collects the directives of every type declared in files.
+func typeDirectivesOf(files []*ast.File) map[token.Pos]directives {
+	found := make(map[token.Pos]directives)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				found[ts.Name.Pos()] = parseDirectives(gd.Doc, ts.Doc, ts.Comment)
+			}
+		}
+	}
+
+	return found
+}
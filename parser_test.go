@@ -2,6 +2,11 @@ package traceable
 
 import (
 	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -57,10 +62,12 @@ func Test_parser_parsePackage(t *testing.T) {
 	t.Log("parsed")
 	c.Assert(err, qt.IsNil)
 	c.Check(pkg.name, qt.Equals, "geometry")
-	c.Assert(pkg.interfaces, qt.HasLen, 1)
+	c.Check(pkg.interfaces, qt.HasLen, 0)
 
 	t.Log("here")
-	i := pkg.interfaces[0]
+	i, err := pkg.lookupInterface("Geometry")
+	c.Assert(err, qt.IsNil)
+	c.Assert(i, qt.IsNotNil)
 	c.Check(i.name, qt.Equals, "Geometry")
 	c.Assert(i.methods, qt.HasLen, 2)
 	t.Log("here again")
@@ -73,3 +80,205 @@ func Test_parser_parsePackage(t *testing.T) {
 	c.Check(i.methods[0].returns[0].String(), qt.Equals, "float64")
 	c.Check(i.methods[0].returns[1].String(), qt.Equals, "error")
 }
+
+func Test_parser_parsePackage_skip(t *testing.T) {
+	pp := &parser{}
+
+	c := qt.New(t)
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedTypesInfo |
+			packages.NeedSyntax |
+			packages.NeedTypes,
+	}
+	pkgs, err := packages.Load(cfg, "github.com/ConorNevin/traceable/internal/tests/cache")
+	c.Assert(err, qt.IsNil)
+	c.Assert(pkgs, qt.HasLen, 1)
+
+	pkg, err := pp.parsePackage(pkgs[0])
+	c.Assert(err, qt.IsNil)
+
+	i, err := pkg.lookupInterface("Cache")
+	c.Assert(err, qt.IsNil)
+	c.Assert(i.methods, qt.HasLen, 2)
+	c.Check(i.methods[0].name, qt.Equals, "Get")
+	c.Check(i.methods[0].skip, qt.IsTrue)
+	c.Check(i.methods[1].name, qt.Equals, "Set")
+	c.Check(i.methods[1].skip, qt.IsFalse)
+}
+
+func Test_parser_parsePackage_async(t *testing.T) {
+	pkg := parseSource(t, `package store
+
+import "context"
+
+type Jobs interface {
+	//traceable:async
+	Enqueue(ctx context.Context, name string) error
+	Status(ctx context.Context, id string) (string, error)
+}
+`)
+
+	i, err := pkg.lookupInterface("Jobs")
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, i.methods, qt.HasLen, 2)
+	qt.Check(t, i.methods[0].name, qt.Equals, "Enqueue")
+	qt.Check(t, i.methods[0].async, qt.IsTrue)
+	qt.Check(t, i.methods[1].name, qt.Equals, "Status")
+	qt.Check(t, i.methods[1].async, qt.IsFalse)
+}
+
+func Test_Package_lookupInterface(t *testing.T) {
+	c := qt.New(t)
+
+	cfg := &packages.Config{Mode: loadMode, ParseFile: parseFile}
+	pkgs, err := packages.Load(cfg, "github.com/ConorNevin/traceable/internal/tests/geometry")
+	c.Assert(err, qt.IsNil)
+	c.Assert(pkgs, qt.HasLen, 1)
+
+	pkg, err := (&parser{}).parsePackage(pkgs[0])
+	c.Assert(err, qt.IsNil)
+
+	for _, name := range []string{"Circle", "NewTracedGeometry", "Missing"} {
+		i, err := pkg.lookupInterface(name)
+		c.Check(err, qt.IsNil)
+		c.Check(i, qt.IsNil, qt.Commentf("%s", name))
+	}
+
+	i, err := pkg.lookupInterface("Geometry")
+	c.Assert(err, qt.IsNil)
+	c.Check(i.methods, qt.HasLen, 2)
+	c.Check(pkg.interfaces, qt.HasLen, 1)
+
+	again, err := pkg.lookupInterface("Geometry")
+	c.Assert(err, qt.IsNil)
+	c.Check(again, qt.Equals, i)
+}
+
+// Benchmark_Generator_ParsePackage loads net/http, a large real-world
+// package, and looks up one of its interfaces. "full" loads and parses the
+// package as traceable used to, with function bodies, type-checking results
+// and every interface in the package parsed; "lean" is how it is loaded now.
+func Benchmark_Generator_ParsePackage(b *testing.B) {
+	const pkgPath = "net/http"
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	b.Run("full", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			cfg := &packages.Config{
+				Mode: packages.NeedName |
+					packages.NeedTypesInfo |
+					packages.NeedSyntax |
+					packages.NeedTypes,
+			}
+			pkgs, err := packages.Load(cfg, pkgPath)
+			if err != nil {
+				b.Fatal(err)
+			}
+			pkg, err := (&parser{}).parsePackage(pkgs[0])
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, name := range pkg.types.Scope().Names() {
+				if _, err := pkg.lookupInterface(name); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("lean", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			g := &Generator{RootPackage: pkgPath, OutputPackagePath: pkgPath}
+			if err := g.ParsePackage([]string{pkgPath}); err != nil {
+				b.Fatal(err)
+			}
+			if i, err := g.pkgs[pkgPath].lookupInterface("RoundTripper"); err != nil || i == nil {
+				b.Fatal("RoundTripper not found", err)
+			}
+		}
+	})
+}
+
+func Test_buildConstraint(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		src  string
+		want string
+	}{
+		{
+			name: "none",
+			src:  "package p\n",
+		},
+		{
+			name: "go:build",
+			src:  "//go:build linux && !cgo\n// +build linux,!cgo\n\npackage p\n",
+			want: "linux && !cgo",
+		},
+		{
+			name: "plus build lines",
+			src:  "// +build linux darwin\n// +build amd64\n\npackage p\n",
+			want: "(linux || darwin) && amd64",
+		},
+		{
+			name: "doc comment",
+			src:  "// Package p does things.\npackage p\n",
+		},
+		{
+			name: "after package clause",
+			src:  "package p\n\n//go:build linux\n",
+		},
+		{
+			name: "GOOS file name",
+			file: "p_linux.go",
+			src:  "package p\n",
+			want: "linux",
+		},
+		{
+			name: "GOARCH file name",
+			file: "p_arm64.go",
+			src:  "package p\n",
+			want: "arm64",
+		},
+		{
+			name: "GOOS and GOARCH test file name",
+			file: "p_windows_amd64_test.go",
+			src:  "//go:build cgo\n\npackage p\n",
+			want: "windows && amd64 && cgo",
+		},
+		{
+			name: "GOOS without a prefix",
+			file: "linux.go",
+			src:  "package p\n",
+		},
+		{
+			name: "unknown suffix",
+			file: "p_linux_other.go",
+			src:  "package p\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			file := tt.file
+			if file == "" {
+				file = "p.go"
+			}
+			f, err := goparser.ParseFile(token.NewFileSet(), file, tt.src, goparser.ParseComments)
+			qt.Assert(t, err, qt.IsNil)
+
+			x := buildConstraint(file, f)
+			if tt.want == "" {
+				qt.Check(t, x, qt.IsNil)
+				return
+			}
+			qt.Assert(t, x, qt.Not(qt.IsNil))
+			qt.Check(t, x.String(), qt.Equals, tt.want)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package traceable
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+)
+
+// sensitiveDirective is the name of the directive marking parameters and
+// struct fields whose values must never be recorded on spans.
+const sensitiveDirective = "sensitive"
+
+// DenyList lists the values that must never be recorded on spans. Tagging a
+// value it matches is refused when the code is generated.
+type DenyList struct {
+	// Names are matched against the names of parameters and fields, and the
+	// keys of tags. A name matches if its last words, which are separated by
+	// punctuation and changes of case, spell one of them, ignoring case and
+	// underscores: authToken and api_key match token and apikey, but
+	// tokenCount and maxTokens do not match token.
+	Names []string
+	// Types are matched against the types of the values, which are written
+	// qualified by either package path or package name, e.g.
+	// *crypto/rsa.PrivateKey or *rsa.PrivateKey. A pointer matches if the
+	// type it points to does.
+	Types []string
+}
+
+// DefaultDenyList is always applied, in addition to the Generator's Deny.
+var DefaultDenyList = DenyList{
+	Names: []string{"password", "passwd", "secret", "secretkey", "token", "apikey", "credential", "credentials", "privatekey"},
+	Types: []string{"crypto.PrivateKey", "*rsa.PrivateKey", "*ecdsa.PrivateKey", "ed25519.PrivateKey"},
+}
+
+// deniedName returns the entry of the deny-lists matching name, if any.
+func (g *Generator) deniedName(name string) string {
+	words := nameWords(name)
+	for _, l := range []DenyList{DefaultDenyList, g.Deny} {
+		for _, denied := range l.Names {
+			if n := normalizeName(denied); n != "" && endsWith(words, n) {
+				return denied
+			}
+		}
+	}
+
+	return ""
+}
+
+// endsWith reports whether the last of words spell name.
+func endsWith(words []string, name string) bool {
+	var suffix string
+	for i := len(words) - 1; i >= 0 && len(suffix) < len(name); i-- {
+		suffix = words[i] + suffix
+	}
+
+	return suffix == name
+}
+
+// nameWords returns the words of name in lower case. Words are separated by
+// anything other than letters and digits, such as underscores and dots, and
+// by changes of case, so APIToken, apiToken and api_token all have the
+// words api and token.
+func nameWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			continue
+		}
+
+		// A word starts at an upper-case letter following a lower-case one
+		// or a digit, or followed by a lower-case one in an acronym.
+		if unicode.IsUpper(r) && i > 0 && len(word) > 0 {
+			prev := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && next {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, unicode.ToLower(r))
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
+
+// deniedType returns the entry of the deny-lists matching t, if any.
+func (g *Generator) deniedType(t types.Type) string {
+	candidates := []types.Type{t}
+	if p, ok := t.(*types.Pointer); ok {
+		candidates = append(candidates, p.Elem())
+	}
+
+	byPath := func(p *types.Package) string { return p.Path() }
+	byName := func(p *types.Package) string { return p.Name() }
+	for _, l := range []DenyList{DefaultDenyList, g.Deny} {
+		for _, denied := range l.Types {
+			for _, c := range candidates {
+				if types.TypeString(c, byPath) == denied || types.TypeString(c, byName) == denied {
+					return denied
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// normalizeName returns name as the words it is matched against would spell
+// it, in lower case and without punctuation.
+func normalizeName(name string) string {
+	return strings.Join(nameWords(name), "")
+}
+
+// validateTags checks that the values the traced methods of the Interface
+// record as tags or baggage items can be read from outputPackage and may be
+// recorded.
+func (g *Generator) validateTags(typeName, outputPackage string) error {
+	for _, m := range g.Interface.methods {
+		if !g.traced(getStructName(typeName), m) {
+			continue
+		}
+
+		for _, tag := range m.tags {
+			if err := g.validateTag(m, tag, outputPackage, fmt.Sprintf("tag %s.%s with %s", typeName, m.name, tag.expr)); err != nil {
+				return err
+			}
+		}
+		for _, tag := range m.baggage {
+			if err := g.validateTag(m, tag, outputPackage, fmt.Sprintf("set baggage of %s.%s from %s", typeName, m.name, tag.expr)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateTag checks that the value of tag can be read from outputPackage
+// and may be recorded, which is described by action.
+func (g *Generator) validateTag(m Method, tag argTag, outputPackage, action string) error {
+	if reason := g.redacted(m, tag); reason != "" {
+		return fmt.Errorf("refusing to %s: %s", action, reason)
+	}
+
+	for _, f := range tag.fields {
+		if !token.IsExported(f.Name()) && f.Pkg().Path() != outputPackage {
+			return fmt.Errorf("can not %s: field %s is unexported and can only be read in package %s", action, f.Name(), f.Pkg().Path())
+		}
+	}
+
+	return nil
+}
+
+// redacted returns why the value of tag must not be recorded, or "" if it
+// may be.
+func (g *Generator) redacted(m Method, tag argTag) string {
+	if tag.sensitive != "" {
+		return fmt.Sprintf("%s is annotated with %s%s", tag.sensitive, directivePrefix, sensitiveDirective)
+	}
+
+	names := []string{m.argNames[tag.arg]}
+	exprs := []string{m.argNames[tag.arg]}
+	path := []types.Type{m.args[tag.arg]}
+	for _, f := range tag.fields {
+		names = append(names, f.Name())
+		exprs = append(exprs, exprs[len(exprs)-1]+"."+f.Name())
+		path = append(path, f.Type())
+	}
+	names = append(names, tag.key)
+	for _, name := range names {
+		if denied := g.deniedName(name); denied != "" {
+			return fmt.Sprintf("the name %s matches %q in the deny-list", name, denied)
+		}
+	}
+
+	// The value must not be read through a deny-listed value either, e.g.
+	// the exponent of a private key.
+	for i, t := range path {
+		denied := g.deniedType(t)
+		switch {
+		case denied == "":
+		case i == len(path)-1:
+			return fmt.Sprintf("its type %s matches %s in the deny-list", types.TypeString(t, nil), denied)
+		default:
+			return fmt.Sprintf("it is read from %s, whose type %s matches %s in the deny-list", exprs[i], types.TypeString(t, nil), denied)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,326 @@
+package traceable
+
+import (
+	"go/ast"
+	"go/importer"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/tools/go/packages"
+)
+
+// parseSource type-checks src as the package example.com/store and returns
+// its parsed Package.
+func parseSource(t *testing.T, src string) *Package {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	f, err := goparser.ParseFile(fset, "store.go", src, goparser.ParseComments)
+	qt.Assert(t, err, qt.IsNil)
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("example.com/store", fset, []*ast.File{f}, nil)
+	qt.Assert(t, err, qt.IsNil)
+
+	p, err := (&parser{}).parsePackage(&packages.Package{
+		Name:    pkg.Name(),
+		PkgPath: pkg.Path(),
+		Fset:    fset,
+		Syntax:  []*ast.File{f},
+		Types:   pkg,
+	})
+	qt.Assert(t, err, qt.IsNil)
+
+	return p
+}
+
+func TestGenerator_validate_tags(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		deny    DenyList
+		output  string
+		wantErr string
+	}{
+		{
+			name: "allowed",
+			method: `//traceable:tag id, user.id=u.ID
+	Update(ctx context.Context, id string, u *User) error`,
+		},
+		{
+			name: "sensitive parameter",
+			method: `//traceable:tag pin
+	//traceable:sensitive pin
+	Login(ctx context.Context, pin string) error`,
+			wantErr: `refusing to tag Store.Login with pin: pin is annotated with //traceable:sensitive`,
+		},
+		{
+			name: "sensitive parameter at end of line",
+			method: `//traceable:tag pin
+	Login(ctx context.Context,
+		pin string, //traceable:sensitive
+	) error`,
+			wantErr: `refusing to tag Store.Login with pin: pin is annotated with //traceable:sensitive`,
+		},
+		{
+			name: "sensitive field",
+			method: `//traceable:tag u.SSN
+	Update(ctx context.Context, u *User) error`,
+			wantErr: `refusing to tag Store.Update with u.SSN: SSN is annotated with //traceable:sensitive`,
+		},
+		{
+			name: "denied name",
+			method: `//traceable:tag pw=newPassword
+	Reset(ctx context.Context, newPassword string) error`,
+			wantErr: `refusing to tag Store.Reset with newPassword: the name newPassword matches "password" in the deny-list`,
+		},
+		{
+			name: "denied field name",
+			method: `//traceable:tag u.APIToken
+	Update(ctx context.Context, u *User) error`,
+			wantErr: `refusing to tag Store.Update with u.APIToken: the name APIToken matches "token" in the deny-list`,
+		},
+		{
+			name: "denied type",
+			method: `//traceable:tag key
+	Sign(ctx context.Context, key *rsa.PrivateKey) error`,
+			wantErr: `refusing to tag Store.Sign with key: its type \*crypto/rsa.PrivateKey matches \*rsa.PrivateKey in the deny-list`,
+		},
+		{
+			name: "field of denied type",
+			method: `//traceable:tag d=key.D
+	Sign(ctx context.Context, key *rsa.PrivateKey) error`,
+			wantErr: `refusing to tag Store.Sign with key.D: it is read from key, whose type \*crypto/rsa.PrivateKey matches \*rsa.PrivateKey in the deny-list`,
+		},
+		{
+			name: "field of project deny-list type",
+			method: `//traceable:tag account.id=a.Owner.ID
+	Open(ctx context.Context, a *Account) error`,
+			deny:    DenyList{Types: []string{"example.com/store.User"}},
+			wantErr: `refusing to tag Store.Open with a.Owner.ID: it is read from a.Owner, whose type \*example.com/store.User matches example.com/store.User in the deny-list`,
+		},
+		{
+			name:    "struct tag of project deny-list type",
+			method:  `Resume(ctx context.Context, s *Session) error`,
+			deny:    DenyList{Types: []string{"example.com/store.Location"}},
+			wantErr: `refusing to tag Store.Resume with s.Location.Region: it is read from s.Location, whose type example.com/store.Location matches example.com/store.Location in the deny-list`,
+		},
+		{
+			name: "project deny-list",
+			method: `//traceable:tag u.Email
+	Update(ctx context.Context, u *User) error`,
+			deny:    DenyList{Names: []string{"e_mail"}},
+			wantErr: `refusing to tag Store.Update with u.Email: the name Email matches "e_mail" in the deny-list`,
+		},
+		{
+			name: "project deny-list type",
+			method: `//traceable:tag u
+	Update(ctx context.Context, u *User) error`,
+			deny:    DenyList{Types: []string{"example.com/store.User"}},
+			wantErr: `refusing to tag Store.Update with u: its type \*example.com/store.User matches example.com/store.User in the deny-list`,
+		},
+		{
+			name: "unexported field",
+			method: `//traceable:tag u.region
+	Update(ctx context.Context, u *User) error`,
+			output:  "example.com/store/traced",
+			wantErr: `can not tag Store.Update with u.region: field region is unexported and can only be read in package example.com/store`,
+		},
+		{
+			name: "untraced method",
+			method: `//traceable:tag pin
+	//traceable:sensitive pin
+	//traceable:skip
+	Login(ctx context.Context, pin string) error`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := parseSource(t, `package store
+
+import (
+	"context"
+	"crypto/rsa"
+)
+
+var _ *rsa.PrivateKey
+
+type User struct {
+	ID       string
+	Email    string
+	APIToken string
+	//traceable:sensitive
+	SSN    string
+	region string
+}
+
+type Account struct {
+	Owner *User
+}
+
+type Session struct {
+	Location Location
+}
+
+type Location struct {
+	Region string `+"`trace:\"region\"`"+`
+}
+
+type Store interface {
+	`+tt.method+`
+}
+`)
+			i, err := pkg.lookupInterface("Store")
+			qt.Assert(t, err, qt.IsNil)
+
+			output := tt.output
+			if output == "" {
+				output = "example.com/store"
+			}
+			g := &Generator{RootPackage: "example.com/store", OutputPackagePath: output, Interface: *i, Deny: tt.deny}
+			err = g.validate("Store")
+			if tt.wantErr == "" {
+				qt.Check(t, err, qt.IsNil)
+				return
+			}
+			qt.Check(t, err, qt.ErrorMatches, tt.wantErr)
+		})
+	}
+}
+
+func TestGenerator_deniedName(t *testing.T) {
+	tests := []struct {
+		name string
+		deny []string
+		want string
+	}{
+		{name: "password", want: "password"},
+		{name: "newPassword", want: "password"},
+		{name: "APIToken", want: "token"},
+		{name: "auth_token", want: "token"},
+		{name: "session.token", want: "token"},
+		{name: "api_key", want: "apikey"},
+		{name: "PrivateKey", want: "privatekey"},
+		{name: "clientSecret", want: "secret"},
+		{name: "secretKey", want: "secretkey"},
+		{name: "Credentials", want: "credentials"},
+		{name: "tokenCount"},
+		{name: "maxTokens"},
+		{name: "passwordPolicyURL"},
+		{name: "id"},
+		{name: "Email", deny: []string{"e_mail"}, want: "e_mail"},
+		{name: "id", deny: []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{Deny: DenyList{Names: tt.deny}}
+			qt.Check(t, g.deniedName(tt.name), qt.Equals, tt.want)
+		})
+	}
+}
+
+func Test_nameWords(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "id", want: []string{"id"}},
+		{name: "userID", want: []string{"user", "id"}},
+		{name: "APIToken", want: []string{"api", "token"}},
+		{name: "api_token", want: []string{"api", "token"}},
+		{name: "search.page-size", want: []string{"search", "page", "size"}},
+		{name: "sha256Sum", want: []string{"sha256", "sum"}},
+		{name: "_", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qt.Check(t, nameWords(tt.name), qt.DeepEquals, tt.want)
+		})
+	}
+}
+
+func Test_parseArgTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "keys",
+			method: `//traceable:tag id, user.id=u.ID, region=u.Profile.Region
+	Update(ctx context.Context, id string, u *User) error`,
+			want: []string{"id=id", "user.id=u.ID", "region=u.Profile.Region"},
+		},
+		{
+			name: "promoted field",
+			method: `//traceable:tag u.Zone
+	Update(ctx context.Context, u *User) error`,
+			want: []string{"u.Zone=u.Profile.Zone"},
+		},
+		{
+			name: "unknown parameter",
+			method: `//traceable:tag user
+	Update(ctx context.Context, u *User) error`,
+			wantErr: `//traceable:tag user on Update: user is not a parameter of Update`,
+		},
+		{
+			name: "unknown field",
+			method: `//traceable:tag u.Name
+	Update(ctx context.Context, u *User) error`,
+			wantErr: `//traceable:tag u.Name on Update: \*example.com/store.User has no field Name`,
+		},
+		{
+			name: "method",
+			method: `//traceable:tag u.String
+	Update(ctx context.Context, u *User) error`,
+			wantErr: `//traceable:tag u.String on Update: \*example.com/store.User has no field String`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := parseSource(t, `package store
+
+import "context"
+
+type User struct {
+	ID string
+	*Profile
+}
+
+func (u *User) String() string { return u.ID }
+
+type Profile struct {
+	Region string
+	Zone   string
+}
+
+type Store interface {
+	`+tt.method+`
+}
+`)
+			i, err := pkg.lookupInterface("Store")
+			if tt.wantErr != "" {
+				qt.Check(t, err, qt.ErrorMatches, tt.wantErr)
+				return
+			}
+			qt.Assert(t, err, qt.IsNil)
+
+			var got []string
+			for _, tag := range i.methods[0].tags {
+				path := i.methods[0].argNames[tag.arg]
+				for _, f := range tag.fields {
+					path += "." + f.Name()
+				}
+				got = append(got, tag.key+"="+path)
+			}
+			qt.Check(t, got, qt.DeepEquals, tt.want)
+		})
+	}
+}
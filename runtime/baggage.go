@@ -0,0 +1,45 @@
+package runtime
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// SetBaggage sets the baggage item key of span, which is propagated to the
+// spans that follow from it, to value formatted as by fmt.Sprint and
+// truncated to maxLen bytes. Values that implement Redactor are masked.
+func SetBaggage(span opentracing.Span, maxLen int, key string, value interface{}) {
+	var s string
+	if r, ok := value.(Redactor); ok {
+		s = redact(r)
+	} else {
+		s = fmt.Sprint(value)
+	}
+	span.SetBaggageItem(key, truncate(s, maxLen))
+}
+
+// TagBaggage sets a tag on span for each of keys that is a baggage item of
+// span, truncating values longer than maxLen bytes.
+func TagBaggage(span opentracing.Span, maxLen int, keys ...string) {
+	for _, key := range keys {
+		if value := span.BaggageItem(key); value != "" {
+			span.SetTag(key, truncate(value, maxLen))
+		}
+	}
+}
+
+// truncate returns the longest prefix of s, no longer than maxLen bytes,
+// that does not split a character.
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+
+	for maxLen > 0 && !utf8.RuneStart(s[maxLen]) {
+		maxLen--
+	}
+
+	return s[:maxLen]
+}
@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestSetBaggage(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "string", value: "acme", want: "acme"},
+		{name: "int", value: 42, want: "42"},
+		{name: "redactor", value: secret("swordfish"), want: "s***"},
+		{name: "nil redactor", value: (*account)(nil), want: Redacted},
+		{name: "truncated", value: strings.Repeat("a", 20), want: strings.Repeat("a", 16)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			tracer := mocktracer.New()
+			o := NewOptions(WithTracer(tracer))
+
+			span, ctx := StartSpan(context.Background(), o, "Client.Call")
+			SetBaggage(span, 16, "tenant.id", tt.value)
+			c.Check(span.BaggageItem("tenant.id"), qt.Equals, tt.want)
+
+			child, _ := StartSpan(ctx, o, "Client.Next")
+			c.Check(child.BaggageItem("tenant.id"), qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestTagBaggage(t *testing.T) {
+	c := qt.New(t)
+	tracer := mocktracer.New()
+	o := NewOptions(WithTracer(tracer))
+
+	parent := tracer.StartSpan("parent")
+	parent.SetBaggageItem("tenant.id", "acme")
+	parent.SetBaggageItem("experiment", strings.Repeat("b", 20))
+	parent.SetBaggageItem("other", "ignored")
+	ctx := opentracing.ContextWithSpan(context.Background(), parent)
+
+	span, _ := StartSpan(ctx, o, "Client.Call")
+	TagBaggage(span, 8, "tenant.id", "experiment", "missing")
+	span.Finish()
+
+	c.Check(span.(*mocktracer.MockSpan).Tags(), qt.DeepEquals, map[string]interface{}{
+		"tenant.id":  "acme",
+		"experiment": strings.Repeat("b", 8),
+	})
+}
+
+func Test_truncate(t *testing.T) {
+	tests := []struct {
+		s      string
+		maxLen int
+		want   string
+	}{
+		{s: "abc", maxLen: 3, want: "abc"},
+		{s: "abcd", maxLen: 3, want: "abc"},
+		{s: "héllo", maxLen: 2, want: "h"},
+		{s: "héllo", maxLen: 3, want: "hé"},
+		{s: "é", maxLen: 1, want: ""},
+		{s: "abc", maxLen: 0, want: ""},
+	}
+	for _, tt := range tests {
+		c := qt.New(t)
+		c.Check(truncate(tt.s, tt.maxLen), qt.Equals, tt.want, qt.Commentf("truncate(%q, %d)", tt.s, tt.maxLen))
+	}
+}
@@ -0,0 +1,13 @@
+//go:build go1.20
+// +build go1.20
+
+package runtime
+
+import (
+	"context"
+)
+
+// contextCause returns the reason ctx was cancelled.
+func contextCause(ctx context.Context) error {
+	return context.Cause(ctx)
+}
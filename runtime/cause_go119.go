@@ -0,0 +1,14 @@
+//go:build !go1.20
+// +build !go1.20
+
+package runtime
+
+import (
+	"context"
+)
+
+// contextCause returns the reason ctx was cancelled. Before Go 1.20 a
+// context carries no cause beyond its error.
+func contextCause(ctx context.Context) error {
+	return ctx.Err()
+}
@@ -0,0 +1,29 @@
+//go:build go1.20
+// +build go1.20
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestFinishSpanWithContext_cause(t *testing.T) {
+	c := qt.New(t)
+	tracer := mocktracer.New()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("client went away"))
+
+	span := tracer.StartSpan("op")
+	FinishSpanWithContext(ctx, span, nil, "Area", nil)
+
+	spans := tracer.FinishedSpans()
+	c.Assert(spans, qt.HasLen, 1)
+	c.Check(spans[0].Tag("context.error"), qt.Equals, "context canceled")
+	c.Check(spans[0].Tag("context.cause"), qt.Equals, "client went away")
+}
@@ -0,0 +1,89 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// Outcome is the result of classifying an error returned by a traced call.
+type Outcome int
+
+const (
+	// Failure marks the span as failed. It is the outcome of any error that
+	// is not otherwise classified.
+	Failure Outcome = iota
+	// Success records the span as though the call returned no error.
+	Success
+	// Expected records the error on the span without marking it as failed.
+	Expected
+)
+
+// Classifier decides the Outcome of an error returned by a traced call.
+type Classifier interface {
+	Classify(err error) Outcome
+}
+
+// DefaultRules are the Rules used when no Classifier is configured. A
+// cancelled context is expected, as it means the caller gave up on the call,
+// whereas an exceeded deadline remains a failure.
+var DefaultRules = Rules{
+	Is(context.Canceled, Expected),
+}
+
+// Rules is a Classifier that returns the Outcome of the first Rule matching
+// an error, or Failure if none match.
+type Rules []Rule
+
+// Classify implements Classifier.
+func (rs Rules) Classify(err error) Outcome {
+	for _, r := range rs {
+		if r.match(err) {
+			return r.outcome
+		}
+	}
+
+	return Failure
+}
+
+// Rule classifies the errors it matches as a given Outcome.
+type Rule struct {
+	match   func(error) bool
+	outcome Outcome
+}
+
+// Is returns a Rule that classifies errors matching target, as reported by
+// errors.Is, as outcome.
+func Is(target error, outcome Outcome) Rule {
+	return Rule{
+		match: func(err error) bool {
+			return errors.Is(err, target)
+		},
+		outcome: outcome,
+	}
+}
+
+// As returns a Rule that classifies errors with a type matching target, as
+// reported by errors.As, as outcome. Like errors.As, it panics if target is
+// not a non-nil pointer to either a type that implements error or to any
+// interface type. target itself is never written to.
+func As(target interface{}, outcome Outcome) Rule {
+	if target == nil {
+		panic("runtime: target cannot be nil")
+	}
+	typ := reflect.TypeOf(target)
+	if typ.Kind() != reflect.Ptr || reflect.ValueOf(target).IsNil() {
+		panic("runtime: target must be a non-nil pointer")
+	}
+	elem := typ.Elem()
+	if elem.Kind() != reflect.Interface && !elem.Implements(reflect.TypeOf((*error)(nil)).Elem()) {
+		panic("runtime: *target must be interface or implement error")
+	}
+
+	return Rule{
+		match: func(err error) bool {
+			return errors.As(err, reflect.New(elem).Interface())
+		},
+		outcome: outcome,
+	}
+}
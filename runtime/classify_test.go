@@ -0,0 +1,96 @@
+package runtime
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRules_Classify(t *testing.T) {
+	rules := Rules{
+		Is(sql.ErrNoRows, Success),
+		As(new(net.Error), Expected),
+		Is(os.ErrNotExist, Expected),
+	}
+
+	tests := []struct {
+		name  string
+		rules Rules
+		err   error
+		want  Outcome
+	}{
+		{
+			name:  "no rules",
+			rules: nil,
+			err:   sql.ErrNoRows,
+			want:  Failure,
+		},
+		{
+			name:  "no matching rule",
+			rules: rules,
+			err:   fmt.Errorf("boom"),
+			want:  Failure,
+		},
+		{
+			name:  "matches sentinel",
+			rules: rules,
+			err:   sql.ErrNoRows,
+			want:  Success,
+		},
+		{
+			name:  "matches wrapped sentinel",
+			rules: rules,
+			err:   fmt.Errorf("find user: %w", sql.ErrNoRows),
+			want:  Success,
+		},
+		{
+			name:  "matches type",
+			rules: rules,
+			err:   fmt.Errorf("dial: %w", &net.DNSError{Err: "no such host"}),
+			want:  Expected,
+		},
+		{
+			name:  "default context cancellation",
+			rules: DefaultRules,
+			err:   fmt.Errorf("search: %w", context.Canceled),
+			want:  Expected,
+		},
+		{
+			name:  "default deadline exceeded",
+			rules: DefaultRules,
+			err:   context.DeadlineExceeded,
+			want:  Failure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qt.Check(t, tt.rules.Classify(tt.err), qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestAs_invalidTarget(t *testing.T) {
+	var netErr *net.DNSError
+
+	tests := []struct {
+		name   string
+		target interface{}
+		want   string
+	}{
+		{"nil target", nil, "runtime: target cannot be nil"},
+		{"not a pointer", netErr, "runtime: target must be a non-nil pointer"},
+		{"does not implement error", new(string), "runtime: \\*target must be interface or implement error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qt.Check(t, func() { As(tt.target, Expected) }, qt.PanicMatches, tt.want)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Package option declares the type of the options that generated wrappers
+// are constructed with. It is separate from the runtime package, which
+// declares the options themselves, so that the pass-through wrappers built
+// with the notrace tag accept the same options without depending on
+// opentracing.
+package option
+
+// Option configures a generated wrapper. It is called with the
+// *runtime.Options being configured; use the functions of the runtime
+// package, such as runtime.WithTracer, to create one.
+type Option func(o interface{})
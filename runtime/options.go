@@ -0,0 +1,82 @@
+package runtime
+
+import (
+	"github.com/ConorNevin/traceable/runtime/option"
+	"github.com/opentracing/opentracing-go"
+)
+
+// Options holds the configuration of a generated wrapper. A nil *Options is
+// valid and uses the defaults.
+type Options struct {
+	tracer      opentracing.Tracer
+	classifier  Classifier
+	classifiers map[string]Classifier
+}
+
+// Option configures the Options of a generated wrapper. It is declared in
+// the option package, which the wrappers generated for the notrace build tag
+// import instead of this one.
+type Option = option.Option
+
+// NewOptions returns Options with opts applied.
+func NewOptions(opts ...Option) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithTracer sets the tracer used to start spans. By default the global
+// tracer is used.
+func WithTracer(tracer opentracing.Tracer) Option {
+	return func(o interface{}) {
+		o.(*Options).tracer = tracer
+	}
+}
+
+// WithClassifier sets the Classifier used to decide whether the errors
+// returned by the wrapped methods mark their spans as failed. By default
+// DefaultRules are used.
+func WithClassifier(c Classifier) Option {
+	return func(o interface{}) {
+		o.(*Options).classifier = c
+	}
+}
+
+// WithMethodClassifier sets the Classifier for errors returned by the named
+// method, taking precedence over WithClassifier.
+func WithMethodClassifier(method string, c Classifier) Option {
+	return func(v interface{}) {
+		o := v.(*Options)
+		if o.classifiers == nil {
+			o.classifiers = make(map[string]Classifier)
+		}
+		o.classifiers[method] = c
+	}
+}
+
+// Classifier returns the Classifier for errors returned by the named method.
+func (o *Options) Classifier(method string) Classifier {
+	if o == nil {
+		return DefaultRules
+	}
+	if c, ok := o.classifiers[method]; ok {
+		return c
+	}
+	if o.classifier != nil {
+		return o.classifier
+	}
+
+	return DefaultRules
+}
+
+// Tracer returns the tracer spans should be started with.
+func (o *Options) Tracer() opentracing.Tracer {
+	if o == nil || o.tracer == nil {
+		return opentracing.GlobalTracer()
+	}
+
+	return o.tracer
+}
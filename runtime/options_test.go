@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"database/sql"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestOptions_Tracer(t *testing.T) {
+	tracer := mocktracer.New()
+
+	tests := []struct {
+		name string
+		o    *Options
+		want opentracing.Tracer
+	}{
+		{
+			name: "nil options",
+			o:    nil,
+			want: opentracing.GlobalTracer(),
+		},
+		{
+			name: "no tracer configured",
+			o:    NewOptions(),
+			want: opentracing.GlobalTracer(),
+		},
+		{
+			name: "tracer configured",
+			o:    NewOptions(WithTracer(tracer)),
+			want: tracer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qt.Check(t, tt.o.Tracer(), qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestOptions_Classifier(t *testing.T) {
+	interfaceRules := Rules{Is(sql.ErrNoRows, Success)}
+	methodRules := Rules{Is(sql.ErrNoRows, Expected)}
+
+	tests := []struct {
+		name   string
+		o      *Options
+		method string
+		want   Outcome
+	}{
+		{
+			name:   "nil options",
+			o:      nil,
+			method: "Get",
+			want:   Failure,
+		},
+		{
+			name:   "no classifier configured",
+			o:      NewOptions(),
+			method: "Get",
+			want:   Failure,
+		},
+		{
+			name:   "interface classifier",
+			o:      NewOptions(WithClassifier(interfaceRules), WithMethodClassifier("Set", methodRules)),
+			method: "Get",
+			want:   Success,
+		},
+		{
+			name:   "method classifier",
+			o:      NewOptions(WithClassifier(interfaceRules), WithMethodClassifier("Get", methodRules)),
+			method: "Get",
+			want:   Expected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.o.Classifier(tt.method).Classify(sql.ErrNoRows)
+			qt.Check(t, got, qt.Equals, tt.want)
+		})
+	}
+}
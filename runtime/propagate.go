@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// Inject injects the context of span into carrier, in format, using the
+// tracer configured in o, so that the span continues in the process the
+// carrier is sent to. Failures are logged on the span.
+func Inject(span opentracing.Span, o *Options, format interface{}, carrier interface{}) {
+	if err := o.Tracer().Inject(span.Context(), format, carrier); err != nil {
+		span.LogFields(log.Event("inject"), log.Error(err))
+	}
+}
+
+// Extract returns an option that makes a span a child of the span context
+// extracted from carrier, in format, using the tracer configured in o. If
+// ctx already has a span, which StartSpan makes the parent, or carrier is
+// nil or has no span context, the option does nothing.
+func Extract(ctx context.Context, o *Options, format interface{}, carrier interface{}) opentracing.StartSpanOption {
+	if opentracing.SpanFromContext(ctx) != nil || isNil(carrier) {
+		return noopOption{}
+	}
+
+	sc, err := o.Tracer().Extract(format, carrier)
+	if err != nil {
+		return noopOption{}
+	}
+
+	return opentracing.ChildOf(sc)
+}
+
+// isNil reports whether carrier is nil, or a nil pointer, which tracers
+// would dereference when reading from it.
+func isNil(carrier interface{}) bool {
+	if carrier == nil {
+		return true
+	}
+	v := reflect.ValueOf(carrier)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+type noopOption struct{}
+
+func (noopOption) Apply(*opentracing.StartSpanOptions) {}
+
+// MetadataCarrier carries span contexts in gRPC metadata, which is keyed by
+// lower-case names. Any map[string][]string, such as a metadata.MD, can be
+// converted to it.
+type MetadataCarrier map[string][]string
+
+// Set implements opentracing.TextMapWriter.
+func (c MetadataCarrier) Set(key, val string) {
+	key = strings.ToLower(key)
+	c[key] = append(c[key], val)
+}
+
+// ForeachKey implements opentracing.TextMapReader.
+func (c MetadataCarrier) ForeachKey(handler func(key, val string) error) error {
+	for key, vals := range c {
+		for _, val := range vals {
+			if err := handler(key, val); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
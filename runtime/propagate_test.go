@@ -0,0 +1,158 @@
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestInjectExtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  interface{}
+		carrier func() interface{}
+	}{
+		{
+			name:    "http.Header",
+			format:  opentracing.HTTPHeaders,
+			carrier: func() interface{} { return opentracing.HTTPHeadersCarrier(http.Header{}) },
+		},
+		{
+			name:    "metadata",
+			format:  opentracing.HTTPHeaders,
+			carrier: func() interface{} { return MetadataCarrier{} },
+		},
+		{
+			name:    "map[string]string",
+			format:  opentracing.TextMap,
+			carrier: func() interface{} { return opentracing.TextMapCarrier{} },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			tracer := mocktracer.New()
+			o := NewOptions(WithTracer(tracer))
+
+			client, _ := StartSpan(context.Background(), o, "Client.Call")
+			carrier := tt.carrier()
+			Inject(client, o, tt.format, carrier)
+			client.Finish()
+
+			server, _ := StartSpan(context.Background(), o, "Server.Call", Extract(context.Background(), o, tt.format, carrier))
+			server.Finish()
+
+			c.Check(server.(*mocktracer.MockSpan).ParentID, qt.Equals, client.(*mocktracer.MockSpan).SpanContext.SpanID)
+			c.Check(server.(*mocktracer.MockSpan).SpanContext.TraceID, qt.Equals, client.(*mocktracer.MockSpan).SpanContext.TraceID)
+		})
+	}
+}
+
+func TestExtract_contextSpan(t *testing.T) {
+	c := qt.New(t)
+	tracer := mocktracer.New()
+	o := NewOptions(WithTracer(tracer))
+
+	remote := tracer.StartSpan("remote")
+	carrier := opentracing.TextMapCarrier{}
+	Inject(remote, o, opentracing.TextMap, carrier)
+
+	parent := tracer.StartSpan("parent")
+	ctx := opentracing.ContextWithSpan(context.Background(), parent)
+	opt := Extract(ctx, o, opentracing.TextMap, carrier)
+	c.Check(opt, qt.Equals, opentracing.StartSpanOption(noopOption{}), qt.Commentf("only the span in the context is a parent"))
+
+	span, _ := StartSpan(ctx, o, "Server.Call", opt)
+	span.Finish()
+	c.Check(span.(*mocktracer.MockSpan).ParentID, qt.Equals, parent.(*mocktracer.MockSpan).SpanContext.SpanID)
+}
+
+func TestExtract_noSpanContext(t *testing.T) {
+	c := qt.New(t)
+	tracer := mocktracer.New()
+	o := NewOptions(WithTracer(tracer))
+
+	span, _ := StartSpan(context.Background(), o, "Server.Call", Extract(context.Background(), o, opentracing.TextMap, opentracing.TextMapCarrier{}))
+	span.Finish()
+
+	c.Check(span.(*mocktracer.MockSpan).ParentID, qt.Equals, 0)
+}
+
+// envelope is a carrier whose methods have pointer receivers, as those
+// annotated with //traceable:carrier often do.
+type envelope struct {
+	attributes map[string]string
+}
+
+func (e *envelope) Set(key, val string) {
+	e.attributes[key] = val
+}
+
+func (e *envelope) ForeachKey(handler func(key, val string) error) error {
+	for key, val := range e.attributes {
+		if err := handler(key, val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestExtract_nilCarrier(t *testing.T) {
+	tests := []struct {
+		name    string
+		carrier interface{}
+	}{
+		{name: "nil", carrier: nil},
+		{name: "nil pointer", carrier: (*envelope)(nil)},
+		{name: "nil map", carrier: opentracing.TextMapCarrier(nil)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			tracer := mocktracer.New()
+			o := NewOptions(WithTracer(tracer))
+
+			span, _ := StartSpan(context.Background(), o, "Server.Call", Extract(context.Background(), o, opentracing.TextMap, tt.carrier))
+			span.Finish()
+
+			c.Check(span.(*mocktracer.MockSpan).ParentID, qt.Equals, 0)
+		})
+	}
+}
+
+func TestInject_unsupportedCarrier(t *testing.T) {
+	c := qt.New(t)
+	tracer := mocktracer.New()
+	o := NewOptions(WithTracer(tracer))
+
+	span, _ := StartSpan(context.Background(), o, "Client.Call")
+	Inject(span, o, opentracing.TextMap, "not a carrier")
+	span.Finish()
+
+	logs := span.(*mocktracer.MockSpan).Logs()
+	c.Assert(logs, qt.HasLen, 1)
+	c.Check(logs[0].Fields[0].ValueString, qt.Equals, "inject")
+}
+
+func TestMetadataCarrier(t *testing.T) {
+	c := qt.New(t)
+
+	md := map[string][]string{}
+	MetadataCarrier(md).Set("Uber-Trace-Id", "1")
+	MetadataCarrier(md).Set("Uber-Trace-Id", "2")
+	c.Check(md, qt.DeepEquals, map[string][]string{"uber-trace-id": {"1", "2"}})
+
+	var got []string
+	err := MetadataCarrier(md).ForeachKey(func(key, val string) error {
+		got = append(got, key+"="+val)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.DeepEquals, []string{"uber-trace-id=1", "uber-trace-id=2"})
+}
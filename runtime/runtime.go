@@ -0,0 +1,29 @@
+// Package runtime contains the support code that wrappers generated by
+// traceable call into. Keeping this logic here, rather than inlining it into
+// every generated method, keeps the generated code small and lets behaviour
+// such as error and panic handling be fixed in one place.
+//
+// The API of this package is versioned. Generated code references a
+// SupportPackageIsVersionN constant so that code generated for a newer
+// version of the package fails to compile against an older one instead of
+// misbehaving at runtime.
+package runtime
+
+// These constants are referenced by generated code to assert that it is
+// compatible with this version of the runtime package.
+const (
+	SupportPackageIsVersion1 = true
+	// SupportPackageIsVersion2 adds error classification, with
+	// FinishSpanWithOptions, SetErrorOutcome and the Classifier options.
+	SupportPackageIsVersion2 = true
+	// SupportPackageIsVersion3 adds FinishSpanWithContext.
+	SupportPackageIsVersion3 = true
+	// SupportPackageIsVersion4 adds Tag.
+	SupportPackageIsVersion4 = true
+	// SupportPackageIsVersion5 adds Inject, Extract and MetadataCarrier.
+	SupportPackageIsVersion5 = true
+	// SupportPackageIsVersion6 adds SetBaggage and TagBaggage.
+	SupportPackageIsVersion6 = true
+	// SupportPackageIsVersion7 adds StartAsyncSpan.
+	SupportPackageIsVersion7 = true
+)
@@ -0,0 +1,113 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// StartSpan starts a span named operationName using the tracer configured in
+// o. The span is a child of the span in ctx, if there is one, and the returned
+// context contains the new span. If ctx has a deadline, the time remaining
+// until it is recorded on the span.
+func StartSpan(ctx context.Context, o *Options, operationName string, opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, o.Tracer(), operationName, opts...)
+	tagDeadline(ctx, span)
+
+	return span, ctx
+}
+
+// StartAsyncSpan starts a span named operationName, for work that outlives
+// the call that started it, using the tracer configured in o. Rather than
+// being a child of the span in ctx, if there is one, the span follows from
+// it, so that the work is not counted in the latency of the caller. The
+// returned context contains the new span.
+func StartAsyncSpan(ctx context.Context, o *Options, operationName string, opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context) {
+	if parent := opentracing.SpanFromContext(ctx); parent != nil {
+		opts = append([]opentracing.StartSpanOption{opentracing.FollowsFrom(parent.Context())}, opts...)
+	}
+	span := o.Tracer().StartSpan(operationName, opts...)
+	ctx = opentracing.ContextWithSpan(ctx, span)
+	tagDeadline(ctx, span)
+
+	return span, ctx
+}
+
+// tagDeadline records the time remaining until the deadline of ctx, if it
+// has one, on span.
+func tagDeadline(ctx context.Context, span opentracing.Span) {
+	if deadline, ok := ctx.Deadline(); ok {
+		span.SetTag("deadline.remaining_ms", time.Until(deadline).Milliseconds())
+	}
+}
+
+// FinishSpan finishes span, marking it as failed if the call it represents
+// returned an error or panicked. err points at the error result of the call
+// and may be nil for methods that do not return an error.
+//
+// FinishSpan must be deferred directly, i.e. defer runtime.FinishSpan(span, &err),
+// as it relies on recover to observe panics. A recovered panic is recorded on
+// the span and then re-raised.
+func FinishSpan(span opentracing.Span, err *error) {
+	// Empty Rules classify every error as a Failure.
+	finishSpan(context.Background(), span, recover(), Rules(nil), err)
+}
+
+// FinishSpanWithOptions is like FinishSpan, but only marks span as failed if
+// the error returned by the call to method is classified as a Failure by o.
+// Like FinishSpan, it must be deferred directly.
+func FinishSpanWithOptions(span opentracing.Span, o *Options, method string, err *error) {
+	finishSpan(context.Background(), span, recover(), o.Classifier(method), err)
+}
+
+// FinishSpanWithContext is like FinishSpanWithOptions, but if ctx, the
+// context the call was made with, is done by the time the call returns, the
+// reason is also recorded on the span. Like FinishSpan, it must be deferred
+// directly.
+func FinishSpanWithContext(ctx context.Context, span opentracing.Span, o *Options, method string, err *error) {
+	finishSpan(ctx, span, recover(), o.Classifier(method), err)
+}
+
+// finishSpan finishes span for the call made with ctx, re-raising p, the
+// value recovered from a panic in the call, if it is not nil. Otherwise the
+// error err points at is recorded according to its classification by c.
+func finishSpan(ctx context.Context, span opentracing.Span, p interface{}, c Classifier, err *error) {
+	if p != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(log.Event("panic"), log.String("message", fmt.Sprint(p)))
+		span.Finish()
+		panic(p)
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		span.SetTag("context.error", ctxErr.Error())
+		if cause := contextCause(ctx); cause != nil && cause != ctxErr {
+			span.SetTag("context.cause", cause.Error())
+		}
+	}
+	if err != nil && *err != nil {
+		SetErrorOutcome(span, *err, c.Classify(*err))
+	}
+	span.Finish()
+}
+
+// SetError marks span as failed because of err.
+func SetError(span opentracing.Span, err error) {
+	SetErrorOutcome(span, err, Failure)
+}
+
+// SetErrorOutcome records err on span according to its outcome.
+func SetErrorOutcome(span opentracing.Span, err error, outcome Outcome) {
+	switch outcome {
+	case Success:
+	case Expected:
+		span.SetTag("error.expected", true)
+		span.LogFields(log.Event("error"), log.Error(err))
+	default:
+		ext.LogError(span, err)
+	}
+}
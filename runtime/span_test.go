@@ -0,0 +1,247 @@
+package runtime
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestStartSpan(t *testing.T) {
+	c := qt.New(t)
+
+	tracer := mocktracer.New()
+	o := NewOptions(WithTracer(tracer))
+
+	parent := tracer.StartSpan("parent")
+	ctx := opentracing.ContextWithSpan(context.Background(), parent)
+
+	span, ctx := StartSpan(ctx, o, "Geometry.Area")
+	span.Finish()
+
+	c.Check(opentracing.SpanFromContext(ctx), qt.Equals, span)
+
+	spans := tracer.FinishedSpans()
+	c.Assert(spans, qt.HasLen, 1)
+	c.Check(spans[0].OperationName, qt.Equals, "Geometry.Area")
+	c.Check(spans[0].ParentID, qt.Equals, parent.(*mocktracer.MockSpan).SpanContext.SpanID)
+}
+
+func TestStartSpan_deadline(t *testing.T) {
+	c := qt.New(t)
+	tracer := mocktracer.New()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	span, _ := StartSpan(ctx, NewOptions(WithTracer(tracer)), "Geometry.Area")
+	span.Finish()
+
+	remaining, ok := span.(*mocktracer.MockSpan).Tag("deadline.remaining_ms").(int64)
+	c.Assert(ok, qt.IsTrue)
+	c.Check(remaining > 0 && remaining <= time.Minute.Milliseconds(), qt.IsTrue, qt.Commentf("remaining %dms", remaining))
+
+	span, _ = StartSpan(context.Background(), NewOptions(WithTracer(tracer)), "Geometry.Area")
+	span.Finish()
+	c.Check(span.(*mocktracer.MockSpan).Tag("deadline.remaining_ms"), qt.IsNil)
+}
+
+// referenceTracer records the references of the spans started with it.
+type referenceTracer struct {
+	*mocktracer.MockTracer
+	refs []opentracing.SpanReference
+}
+
+func (t *referenceTracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	var sso opentracing.StartSpanOptions
+	for _, o := range opts {
+		o.Apply(&sso)
+	}
+	t.refs = append(t.refs, sso.References...)
+
+	return t.MockTracer.StartSpan(operationName, opts...)
+}
+
+func TestStartAsyncSpan(t *testing.T) {
+	c := qt.New(t)
+
+	tracer := &referenceTracer{MockTracer: mocktracer.New()}
+	o := NewOptions(WithTracer(tracer))
+
+	parent := tracer.StartSpan("parent")
+	tracer.refs = nil
+	ctx := opentracing.ContextWithSpan(context.Background(), parent)
+
+	span, ctx := StartAsyncSpan(ctx, o, "Jobs.Enqueue", opentracing.Tag{Key: "span.kind", Value: "producer"})
+	span.Finish()
+
+	c.Check(opentracing.SpanFromContext(ctx), qt.Equals, span)
+	c.Assert(tracer.refs, qt.HasLen, 1)
+	c.Check(tracer.refs[0].Type, qt.Equals, opentracing.FollowsFromRef)
+	c.Check(tracer.refs[0].ReferencedContext.(mocktracer.MockSpanContext).SpanID, qt.Equals, parent.(*mocktracer.MockSpan).SpanContext.SpanID)
+	c.Check(span.(*mocktracer.MockSpan).Tag("span.kind"), qt.Equals, "producer")
+
+	tracer.refs = nil
+	span, _ = StartAsyncSpan(context.Background(), o, "Jobs.Enqueue")
+	span.Finish()
+	c.Check(tracer.refs, qt.HasLen, 0)
+}
+
+func TestFinishSpanWithContext(t *testing.T) {
+	c := qt.New(t)
+	tracer := mocktracer.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	func() (err error) {
+		span := tracer.StartSpan("op")
+		defer FinishSpanWithContext(ctx, span, nil, "Area", &err)
+		return ctx.Err()
+	}()
+
+	spans := tracer.FinishedSpans()
+	c.Assert(spans, qt.HasLen, 1)
+	c.Check(spans[0].Tag("context.error"), qt.Equals, "context canceled")
+	c.Check(spans[0].Tag("context.cause"), qt.IsNil)
+	c.Check(spans[0].Tag("error.expected"), qt.Equals, true)
+}
+
+func TestFinishSpan(t *testing.T) {
+	c := qt.New(t)
+	tracer := mocktracer.New()
+
+	func() (err error) {
+		span := tracer.StartSpan("op")
+		defer FinishSpan(span, &err)
+		return fmt.Errorf("search: %w", context.Canceled)
+	}()
+
+	spans := tracer.FinishedSpans()
+	c.Assert(spans, qt.HasLen, 1)
+	c.Check(spans[0].Tag("error"), qt.Equals, true, qt.Commentf("errors are not classified"))
+	c.Check(spans[0].Tag("error.expected"), qt.IsNil)
+}
+
+func TestFinishSpanWithOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		noErr        bool
+		o            *Options
+		wantError    bool
+		wantExpected bool
+	}{
+		{
+			name:  "method without an error result",
+			noErr: true,
+		},
+		{
+			name: "nil error",
+		},
+		{
+			name:      "non-nil error",
+			err:       errors.New("boom"),
+			wantError: true,
+		},
+		{
+			name:         "expected error",
+			err:          fmt.Errorf("search: %w", context.Canceled),
+			wantExpected: true,
+		},
+		{
+			name: "error classified as success",
+			err:  sql.ErrNoRows,
+			o:    NewOptions(WithMethodClassifier("Area", Rules{Is(sql.ErrNoRows, Success)})),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			tracer := mocktracer.New()
+
+			func() (err error) {
+				span := tracer.StartSpan("op")
+				if tt.noErr {
+					defer FinishSpanWithOptions(span, tt.o, "Area", nil)
+				} else {
+					defer FinishSpanWithOptions(span, tt.o, "Area", &err)
+				}
+				return tt.err
+			}()
+
+			spans := tracer.FinishedSpans()
+			c.Assert(spans, qt.HasLen, 1)
+			if tt.wantExpected {
+				c.Check(spans[0].Tag("error"), qt.IsNil)
+				c.Check(spans[0].Tag("error.expected"), qt.Equals, true)
+				c.Assert(spans[0].Logs(), qt.HasLen, 1)
+				return
+			}
+			if !tt.wantError {
+				c.Check(spans[0].Tag("error"), qt.IsNil)
+				c.Check(spans[0].Logs(), qt.HasLen, 0)
+				return
+			}
+
+			c.Check(spans[0].Tag("error"), qt.Equals, true)
+			c.Assert(spans[0].Logs(), qt.HasLen, 1)
+			c.Check(spans[0].Logs()[0].Fields[0].ValueString, qt.Equals, "error")
+		})
+	}
+}
+
+func TestFinishSpan_panic(t *testing.T) {
+	tests := []struct {
+		name   string
+		finish func(span opentracing.Span)
+	}{
+		{
+			name: "FinishSpan",
+			finish: func(span opentracing.Span) {
+				defer FinishSpan(span, nil)
+				panic("boom")
+			},
+		},
+		{
+			name: "FinishSpanWithOptions",
+			finish: func(span opentracing.Span) {
+				defer FinishSpanWithOptions(span, nil, "Area", nil)
+				panic("boom")
+			},
+		},
+		{
+			name: "FinishSpanWithContext",
+			finish: func(span opentracing.Span) {
+				defer FinishSpanWithContext(context.Background(), span, nil, "Area", nil)
+				panic("boom")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			tracer := mocktracer.New()
+
+			c.Check(func() { tt.finish(tracer.StartSpan("op")) }, qt.PanicMatches, "boom")
+
+			spans := tracer.FinishedSpans()
+			c.Assert(spans, qt.HasLen, 1)
+			c.Check(spans[0].Tag("error"), qt.Equals, true)
+			c.Assert(spans[0].Logs(), qt.HasLen, 1)
+			c.Check(spans[0].Logs()[0].Fields, qt.DeepEquals, []mocktracer.MockKeyValue{
+				{Key: "event", ValueKind: reflect.String, ValueString: "panic"},
+				{Key: "message", ValueKind: reflect.String, ValueString: "boom"},
+			})
+		})
+	}
+}
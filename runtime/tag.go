@@ -0,0 +1,34 @@
+package runtime
+
+import "github.com/opentracing/opentracing-go"
+
+// Redacted is recorded in place of values that could not be redacted.
+const Redacted = "[REDACTED]"
+
+// Redactor is implemented by values that must not be recorded on spans as
+// they are, such as credentials or personal data. Tag records the result of
+// Redact in their place.
+type Redactor interface {
+	Redact() string
+}
+
+// Tag sets the tag key on span to value, masking values that implement
+// Redactor.
+func Tag(span opentracing.Span, key string, value interface{}) {
+	if r, ok := value.(Redactor); ok {
+		value = redact(r)
+	}
+	span.SetTag(key, value)
+}
+
+// redact returns the redacted form of r, or Redacted if Redact panics, e.g.
+// because r is a nil pointer.
+func redact(r Redactor) (s string) {
+	defer func() {
+		if recover() != nil {
+			s = Redacted
+		}
+	}()
+
+	return r.Redact()
+}
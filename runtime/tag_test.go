@@ -0,0 +1,60 @@
+package runtime
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+type secret string
+
+func (s secret) Redact() string {
+	return "s***"
+}
+
+type account struct {
+	key string
+}
+
+func (a *account) Redact() string {
+	return a.key[:1] + "***"
+}
+
+func TestTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{
+			name:  "plain",
+			value: 42,
+			want:  42,
+		},
+		{
+			name:  "redactor",
+			value: secret("swordfish"),
+			want:  "s***",
+		},
+		{
+			name:  "pointer redactor",
+			value: &account{key: "acct_123"},
+			want:  "a***",
+		},
+		{
+			name:  "nil pointer redactor",
+			value: (*account)(nil),
+			want:  Redacted,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			span := mocktracer.New().StartSpan("op")
+			Tag(span, "value", tt.value)
+			qt.Check(t, span.(*mocktracer.MockSpan).Tag("value"), qt.Equals, tt.want)
+		})
+	}
+}
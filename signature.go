@@ -0,0 +1,533 @@
+package traceable
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/build"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"hash"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// signatureDirective is the name of the directive recording the
+	// signature of the interface a file was generated from in its header.
+	signatureDirective = "signature"
+
+	// generatorVersion is written to signatures so that files generated by
+	// an older traceable are not taken to be up to date. It must be bumped
+	// whenever a change to traceable changes the code it generates.
+	generatorVersion = 1
+)
+
+// foundPackages caches the packages found by signers, keyed by the
+// directory they were imported from and their import path. Finding a package
+// outside the standard library runs the go command, so only its directory is
+// remembered and its files are listed again each time.
+var foundPackages = struct {
+	sync.Mutex
+	m map[[2]string]*build.Package
+}{m: make(map[[2]string]*build.Package)}
+
+// UpToDate reports whether the file name was generated for types, with the
+// Generator's arguments, from the current declarations of the interfaces in
+// the Go files in dir, according to the signatures recorded in its header.
+// Only the files in dir are parsed; no packages are loaded or type-checked,
+// so this is much cheaper than generating the file again.
+func (g *Generator) UpToDate(name, dir string, types []string) bool {
+	recorded, err := recordedSignatures(name)
+	if err != nil || len(recorded) != len(types) {
+		return false
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := goparser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return g.matchFile(dir, fi)
+	}, goparser.ParseComments)
+	if err != nil {
+		return false
+	}
+
+	var files []*ast.File
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.Name, "_test") && len(pkgs) > 1 {
+			// Interfaces are never generated from external test packages.
+			continue
+		}
+
+		var names []string
+		for name := range pkg.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			files = append(files, pkg.Files[name])
+		}
+	}
+
+	for i, typeName := range types {
+		sig, ok := g.signature(typeName, fset, files)
+		if !ok || sig != recorded[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// signature returns a hash of everything the code generated for typeName
+// depends on: the declaration of the interface in files, which were parsed
+// into fset, those of the types its methods refer to, whatever package they
+// are declared in, and of the extractors of the ContextTags, along with the
+// arguments the Generator was run with, the packages it generates from and
+// into and the version of traceable. ok is false if a declaration can not be
+// found.
+func (g *Generator) signature(typeName string, fset *token.FileSet, files []*ast.File) (sig string, ok bool) {
+	if strings.ContainsRune(typeName, '.') || len(files) == 0 {
+		return "", false
+	}
+	dir, err := filepath.Abs(filepath.Dir(fset.File(files[0].Pos()).Name()))
+	if err != nil {
+		return "", false
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "generator %d\nruntime %d\n", generatorVersion, runtimeVersion)
+	fmt.Fprintf(h, "root %s\noutput %s\n", g.RootPackage, g.OutputPackagePath)
+	for _, arg := range g.args() {
+		fmt.Fprintf(h, "arg %q\n", arg)
+	}
+
+	root := newSigPackage(g.RootPackage, files)
+	ctxt := g.buildContext()
+	ctxt.Dir = dir
+	s := signer{
+		h:    h,
+		fset: fset,
+		ctxt: ctxt,
+		dir:  dir,
+		pkgs: map[string]*sigPackage{g.RootPackage: root},
+		seen: make(map[string]bool),
+	}
+	if ts, ok := root.specs[typeName]; !ok || !isInterfaceSpec(ts.spec) {
+		return "", false
+	}
+	if !s.writeType(root, typeName) || !s.writeExtractors(g.ContextTags) {
+		return "", false
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:16]), true
+}
+
+// typeSpec is the declaration of a type along with the file it is in.
+type typeSpec struct {
+	file *ast.File
+	decl *ast.GenDecl
+	spec *ast.TypeSpec
+}
+
+// funcSpec is the declaration of a function along with the file it is in.
+type funcSpec struct {
+	file *ast.File
+	decl *ast.FuncDecl
+}
+
+// sigPackage holds the declarations of a package that signatures can
+// depend on.
+type sigPackage struct {
+	path string
+	name string
+	// std is set for packages of the standard library, whose declarations
+	// are not parsed. The Go 1 compatibility promise keeps their types from
+	// changing in ways that affect generated code, so they are only written
+	// to the hash by name.
+	std   bool
+	specs map[string]typeSpec
+	funcs map[string]funcSpec
+}
+
+func newSigPackage(path string, files []*ast.File) *sigPackage {
+	pkg := &sigPackage{
+		path:  path,
+		specs: make(map[string]typeSpec),
+		funcs: make(map[string]funcSpec),
+	}
+	for _, f := range files {
+		pkg.name = f.Name.Name
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				if decl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					ts := spec.(*ast.TypeSpec)
+					pkg.specs[ts.Name.Name] = typeSpec{file: f, decl: decl, spec: ts}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					pkg.funcs[decl.Name.Name] = funcSpec{file: f, decl: decl}
+				}
+			}
+		}
+	}
+
+	return pkg
+}
+
+// signer writes the declarations of an interface, and of everything it
+// refers to, to a hash.
+type signer struct {
+	h    hash.Hash
+	fset *token.FileSet
+	// ctxt and dir are the build context and directory that the packages
+	// imported by the declarations are found with.
+	ctxt build.Context
+	dir  string
+	// pkgs are the packages found so far by import path, or nil if they
+	// could not be.
+	pkgs map[string]*sigPackage
+	seen map[string]bool
+}
+
+// importPackage returns the package at path, parsing it the first time it
+// is imported.
+func (s *signer) importPackage(importPath string) (*sigPackage, bool) {
+	if pkg, ok := s.pkgs[importPath]; ok {
+		return pkg, pkg != nil
+	}
+	s.pkgs[importPath] = nil
+
+	if importPath == "C" {
+		s.pkgs[importPath] = &sigPackage{path: importPath, name: importPath, std: true}
+		return s.pkgs[importPath], true
+	}
+	bp, err := s.findPackage(importPath)
+	if err != nil {
+		return nil, false
+	}
+	if bp.Goroot {
+		s.pkgs[importPath] = &sigPackage{path: importPath, name: bp.Name, std: true}
+		return s.pkgs[importPath], true
+	}
+
+	var files []*ast.File
+	for _, name := range append(bp.GoFiles, bp.CgoFiles...) {
+		f, err := goparser.ParseFile(s.fset, filepath.Join(bp.Dir, name), nil, goparser.ParseComments)
+		if err != nil {
+			return nil, false
+		}
+		files = append(files, f)
+	}
+	s.pkgs[importPath] = newSigPackage(importPath, files)
+
+	return s.pkgs[importPath], true
+}
+
+// findPackage returns the build information of the package at importPath.
+func (s *signer) findPackage(importPath string) (*build.Package, error) {
+	key := [2]string{s.dir, importPath}
+	foundPackages.Lock()
+	bp, ok := foundPackages.m[key]
+	foundPackages.Unlock()
+	if !ok {
+		var err error
+		if bp, err = s.ctxt.Import(importPath, s.dir, 0); err != nil {
+			return nil, err
+		}
+		foundPackages.Lock()
+		foundPackages.m[key] = bp
+		foundPackages.Unlock()
+		return bp, nil
+	}
+	if bp.Goroot {
+		return bp, nil
+	}
+
+	return s.ctxt.ImportDir(bp.Dir, 0)
+}
+
+// resolve returns the package that file imports as name.
+func (s *signer) resolve(file *ast.File, name string) (*sigPackage, bool) {
+	var unnamed []string
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, false
+		}
+		if spec.Name == nil {
+			unnamed = append(unnamed, importPath)
+		} else if spec.Name.Name == name {
+			return s.importPackage(importPath)
+		}
+	}
+
+	// Packages are usually named after the last element of their import
+	// path, so those are tried first.
+	sort.SliceStable(unnamed, func(i, j int) bool {
+		return path.Base(unnamed[i]) == name && path.Base(unnamed[j]) != name
+	})
+	for _, importPath := range unnamed {
+		if pkg, ok := s.importPackage(importPath); ok && pkg.name == name {
+			return pkg, true
+		}
+	}
+
+	return nil, false
+}
+
+// writeType writes the declaration of the type name in pkg, and of the
+// types it refers to, to the hash. It reports false if a declaration can
+// not be found.
+func (s *signer) writeType(pkg *sigPackage, name string) bool {
+	key := pkg.path + "." + name
+	if s.seen[key] {
+		return true
+	}
+	s.seen[key] = true
+
+	if pkg.std {
+		fmt.Fprintf(s.h, "std %s\n", key)
+		return true
+	}
+	ts, ok := pkg.specs[name]
+	if !ok {
+		return false
+	}
+
+	fmt.Fprintf(s.h, "type %s\n", key)
+	if x := buildConstraint(s.fset.File(ts.file.Pos()).Name(), ts.file); x != nil {
+		fmt.Fprintf(s.h, "build %s\n", x)
+	}
+	if ts.spec.Assign.IsValid() {
+		fmt.Fprintf(s.h, "alias\n")
+	}
+	s.writeDirectives(ts.decl.Doc, ts.spec.Doc, ts.spec.Comment)
+
+	switch t := ts.spec.Type.(type) {
+	case *ast.InterfaceType:
+		return s.writeInterface(pkg, ts.file, t)
+	case *ast.StructType:
+		return s.writeStruct(pkg, ts.file, t)
+	default:
+		fmt.Fprintf(s.h, "underlying %s\n", types.ExprString(t))
+		return s.writeExpr(pkg, ts.file, t)
+	}
+}
+
+// writeInterface writes the methods of the interface it, declared in file,
+// and the interfaces it embeds to the hash.
+func (s *signer) writeInterface(pkg *sigPackage, file *ast.File, it *ast.InterfaceType) bool {
+	for _, field := range it.Methods.List {
+		if len(field.Names) == 0 {
+			fmt.Fprintf(s.h, "embed %s\n", types.ExprString(field.Type))
+		}
+		for _, n := range field.Names {
+			fmt.Fprintf(s.h, "method %s %s\n", n.Name, types.ExprString(field.Type))
+		}
+		s.writeDirectives(field.Doc, field.Comment)
+		if !s.writeExpr(pkg, file, field.Type) {
+			return false
+		}
+	}
+
+	// Directives can also annotate parameters at the end of their lines.
+	for _, g := range file.Comments {
+		if g.Pos() < it.Pos() || g.End() > it.End() {
+			continue
+		}
+		for _, c := range g.List {
+			if strings.HasPrefix(c.Text, directivePrefix) {
+				fmt.Fprintf(s.h, "comment %q\n", c.Text)
+			}
+		}
+	}
+
+	return true
+}
+
+// writeStruct writes the fields of the struct st, declared in file, to the
+// hash, as the generated code reads them according to their trace struct
+// tags. Only the types of exported fields, which are the ones read, are
+// written in turn.
+func (s *signer) writeStruct(pkg *sigPackage, file *ast.File, st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		var tag string
+		if field.Tag != nil {
+			tag = field.Tag.Value
+		}
+		fmt.Fprintf(s.h, "field %s %s %s\n", fieldNames(field), types.ExprString(field.Type), tag)
+		s.writeDirectives(field.Doc, field.Comment)
+		if isExportedField(field) && !s.writeExpr(pkg, file, field.Type) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// writeExpr writes the declarations of the types that the type expression
+// x, in file, refers to to the hash.
+func (s *signer) writeExpr(pkg *sigPackage, file *ast.File, x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident:
+		if _, ok := pkg.specs[x.Name]; ok {
+			return s.writeType(pkg, x.Name)
+		}
+		// Names that are not declared in the package are predeclared,
+		// e.g. error, unless they are dot-imported.
+		return types.Universe.Lookup(x.Name) != nil
+	case *ast.SelectorExpr:
+		id, ok := x.X.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		imported, ok := s.resolve(file, id.Name)
+		return ok && s.writeType(imported, x.Sel.Name)
+	case *ast.ParenExpr:
+		return s.writeExpr(pkg, file, x.X)
+	case *ast.StarExpr:
+		return s.writeExpr(pkg, file, x.X)
+	case *ast.Ellipsis:
+		return s.writeExpr(pkg, file, x.Elt)
+	case *ast.ArrayType:
+		return s.writeExpr(pkg, file, x.Elt)
+	case *ast.MapType:
+		return s.writeExpr(pkg, file, x.Key) && s.writeExpr(pkg, file, x.Value)
+	case *ast.ChanType:
+		return s.writeExpr(pkg, file, x.Value)
+	case *ast.FuncType:
+		return s.writeFields(pkg, file, x.Params) && s.writeFields(pkg, file, x.Results)
+	case *ast.StructType:
+		return s.writeStruct(pkg, file, x)
+	case *ast.InterfaceType:
+		return s.writeInterface(pkg, file, x)
+	}
+
+	return false
+}
+
+func (s *signer) writeFields(pkg *sigPackage, file *ast.File, fields *ast.FieldList) bool {
+	if fields == nil {
+		return true
+	}
+	for _, field := range fields.List {
+		if !s.writeExpr(pkg, file, field.Type) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// writeExtractors writes the declarations of the extractor functions of
+// tags, whose results the generated code records, to the hash.
+func (s *signer) writeExtractors(tags []ContextTag) bool {
+	for _, c := range tags {
+		importPath, name := c.split()
+		pkg, ok := s.importPackage(importPath)
+		if !ok {
+			return false
+		}
+		if pkg.std {
+			fmt.Fprintf(s.h, "extractor %s std %s\n", c.Key, c.Func)
+			continue
+		}
+
+		fn, ok := pkg.funcs[name]
+		if !ok {
+			return false
+		}
+		fmt.Fprintf(s.h, "extractor %s %s %s\n", c.Key, c.Func, types.ExprString(fn.decl.Type))
+		if !s.writeExpr(pkg, fn.file, fn.decl.Type) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isInterfaceSpec(ts *ast.TypeSpec) bool {
+	_, ok := ts.Type.(*ast.InterfaceType)
+	return ok
+}
+
+// isExportedField reports whether field declares exported fields, or embeds
+// an exported type.
+func isExportedField(field *ast.Field) bool {
+	for _, n := range field.Names {
+		if n.IsExported() {
+			return true
+		}
+	}
+	if len(field.Names) > 0 {
+		return false
+	}
+
+	x := field.Type
+	if star, ok := x.(*ast.StarExpr); ok {
+		x = star.X
+	}
+	switch x := x.(type) {
+	case *ast.Ident:
+		return x.IsExported()
+	case *ast.SelectorExpr:
+		return x.Sel.IsExported()
+	}
+
+	return false
+}
+
+func fieldNames(field *ast.Field) string {
+	names := make([]string, len(field.Names))
+	for i, n := range field.Names {
+		names[i] = n.Name
+	}
+
+	return strings.Join(names, ",")
+}
+
+func (s *signer) writeDirectives(groups ...*ast.CommentGroup) {
+	d := parseDirectives(groups...)
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(s.h, "directive %s %q\n", name, d[name])
+	}
+}
+
+// recordedSignatures returns the signatures recorded in the headers of the
+// file name, in the order they appear.
+func recordedSignatures(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var sigs []string
+	prefix := directivePrefix + signatureDirective + " "
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := s.Text(); strings.HasPrefix(line, prefix) {
+			sigs = append(sigs, strings.TrimPrefix(line, prefix))
+		}
+	}
+
+	return sigs, s.Err()
+}
@@ -0,0 +1,375 @@
+package traceable
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestGenerator_UpToDate(t *testing.T) {
+	const source = `package store
+
+import "context"
+
+type Store interface {
+	Reader
+	//traceable:skip
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+type Reader interface {
+	Read(ctx context.Context, r Request) (Values, error)
+}
+
+type Request struct {
+	Query string
+}
+
+type Values []string
+`
+
+	tests := []struct {
+		name   string
+		source string
+		args   []string
+		want   bool
+	}{
+		{
+			name:   "unchanged",
+			source: source,
+			want:   true,
+		},
+		{
+			name: "comments and formatting",
+			source: `package store
+
+import "context"
+
+// Store stores values.
+type Store interface {
+	Reader
+
+	// Get gets a value.
+	//traceable:skip
+	Get(ctx context.Context,
+		key string) ([]byte, error)
+}
+
+type Reader interface {
+	Read(ctx context.Context, r Request) (Values, error)
+}
+
+type Request struct {
+	Query string
+}
+
+type Values []string
+`,
+			want: true,
+		},
+		{
+			name:   "method",
+			source: strings.Replace(source, "\tReader\n", "\tReader\n\tClose() error\n", 1),
+		},
+		{
+			name: "directive",
+			source: `package store
+
+import "context"
+
+type Store interface {
+	Reader
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+type Reader interface {
+	Read(ctx context.Context, r Request) (Values, error)
+}
+
+type Request struct {
+	Query string
+}
+
+type Values []string
+`,
+		},
+		{
+			name: "embedded interface",
+			source: `package store
+
+import "context"
+
+type Store interface {
+	Reader
+	//traceable:skip
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+type Reader interface {
+	Read(ctx context.Context, r Request, n int) (Values, error)
+}
+
+type Request struct {
+	Query string
+}
+
+type Values []string
+`,
+		},
+		{
+			name: "import",
+			source: `package store
+
+import context "example.com/context"
+
+type Store interface {
+	Reader
+	//traceable:skip
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+type Reader interface {
+	Read(ctx context.Context, r Request) (Values, error)
+}
+
+type Request struct {
+	Query string
+}
+
+type Values []string
+`,
+		},
+		{
+			name:   "build constraint",
+			source: "//go:build !plan9\n\n" + source,
+		},
+		{
+			name:   "struct tag",
+			source: strings.Replace(source, "Query string", "Query string `trace:\"query\"`", 1),
+		},
+		{
+			name:   "carrier",
+			source: strings.Replace(source, "type Request", "//traceable:carrier\ntype Request", 1),
+		},
+		{
+			name:   "named type",
+			source: strings.Replace(source, "type Values []string", "type Values [4]string", 1),
+		},
+		{
+			name:   "unreferenced type",
+			source: source + "\ntype Response struct {\n\tResults int `trace:\"results\"`\n}\n",
+			want:   true,
+		},
+		{
+			name:   "arguments",
+			source: source,
+			args:   []string{"-types", "Store", "-fake"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			output := filepath.Join(dir, "store_traced.go")
+			g := &Generator{
+				RootPackage:       "example.com/store",
+				OutputPackagePath: "example.com/store",
+				Args:              []string{"-types", "Store"},
+			}
+
+			writeSource(t, dir, source)
+			qt.Assert(t, g.UpToDate(output, dir, []string{"Store"}), qt.IsFalse)
+
+			sig := signatureOf(t, g, dir, "Store")
+			qt.Assert(t, ioutil.WriteFile(output, []byte("// Code generated by \"traceable\"; DO NOT EDIT.\n//traceable:signature "+sig+"\n\npackage store\n"), 0644), qt.IsNil)
+			qt.Assert(t, g.UpToDate(output, dir, []string{"Store"}), qt.IsTrue)
+
+			writeSource(t, dir, tt.source)
+			if tt.args != nil {
+				g.Args = tt.args
+			}
+			qt.Check(t, g.UpToDate(output, dir, []string{"Store"}), qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestGenerator_signature_otherPackage(t *testing.T) {
+	dir := t.TempDir()
+	writeSource(t, dir, `package store
+
+import "io"
+
+type Store interface {
+	io.Closer
+}
+`)
+	fset, files := parseSources(t, dir)
+
+	_, ok := (&Generator{}).signature("Store", fset, files)
+	qt.Check(t, ok, qt.IsTrue)
+	_, ok = (&Generator{}).signature("io.Closer", fset, files)
+	qt.Check(t, ok, qt.IsFalse)
+
+	writeSource(t, dir, `package store
+
+import "example.com/missing"
+
+type Store interface {
+	missing.Closer
+}
+`)
+	fset, files = parseSources(t, dir)
+	_, ok = (&Generator{}).signature("Store", fset, files)
+	qt.Check(t, ok, qt.IsFalse)
+}
+
+func TestGenerator_signature_imports(t *testing.T) {
+	const (
+		query = `package query
+
+// Request is a query.
+type Request struct {
+	Region string ` + "`trace:\"region\"`" + `
+	limit  int
+}
+
+type Results []string
+`
+		auth = `package auth
+
+import "context"
+
+func Tenant(ctx context.Context) (string, bool) {
+	return "", false
+}
+`
+	)
+
+	tests := []struct {
+		name  string
+		file  string
+		src   string
+		equal bool
+	}{
+		{
+			name:  "comments",
+			file:  "query/query.go",
+			src:   strings.Replace(query, "// Request is a query.", "// Request is a search query.", 1),
+			equal: true,
+		},
+		{
+			name: "struct tag",
+			file: "query/query.go",
+			src:  strings.Replace(query, `trace:"region"`, `trace:"query.region"`, 1),
+		},
+		{
+			name: "named type",
+			file: "query/query.go",
+			src:  strings.Replace(query, "type Results []string", "type Results [4]string", 1),
+		},
+		{
+			// The package was found by the first signature; the files in it
+			// are listed again by the second.
+			name: "new file",
+			file: "query/results.go",
+			src:  "package query\n\ntype Results [4]string\n",
+		},
+		{
+			name: "extractor",
+			file: "auth/auth.go",
+			src:  strings.Replace(strings.Replace(auth, "(string, bool)", "string", 1), `"", false`, `""`, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{
+				"go.mod":         "module example.com/store\n\ngo 1.16\n",
+				"query/query.go": query,
+				"auth/auth.go":   auth,
+			}
+			for name, src := range files {
+				qt.Assert(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755), qt.IsNil)
+				qt.Assert(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0644), qt.IsNil)
+			}
+			writeSource(t, dir, `package store
+
+import (
+	"context"
+
+	"example.com/store/query"
+)
+
+type Store interface {
+	Search(ctx context.Context, r *query.Request) (query.Results, error)
+}
+`)
+			g := &Generator{
+				RootPackage:       "example.com/store",
+				OutputPackagePath: "example.com/store",
+				ContextTags:       []ContextTag{{Key: "tenant", Func: "example.com/store/auth.Tenant"}},
+			}
+
+			want := signatureOf(t, g, dir, "Store")
+			qt.Assert(t, ioutil.WriteFile(filepath.Join(dir, tt.file), []byte(tt.src), 0644), qt.IsNil)
+			got := signatureOf(t, g, dir, "Store")
+			qt.Check(t, got == want, qt.Equals, tt.equal)
+		})
+	}
+}
+
+func TestGenerator_signature_generatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeSource(t, dir, `package store
+
+import "context"
+
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+`)
+	fset, files := parseSources(t, dir)
+	want, ok := (&Generator{}).signature("Store", fset, files)
+	qt.Assert(t, ok, qt.IsTrue)
+
+	generated, err := goparser.ParseFile(fset, "store_traced.go", `// Code generated by "traceable -types Store"; DO NOT EDIT.
+
+package store
+
+type TracedStore struct {
+	x Store
+}
+`, goparser.ParseComments)
+	qt.Assert(t, err, qt.IsNil)
+
+	got, ok := (&Generator{}).signature("Store", fset, append(files, generated))
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Check(t, got, qt.Equals, want)
+}
+
+func writeSource(t *testing.T, dir, src string) {
+	t.Helper()
+	qt.Assert(t, ioutil.WriteFile(filepath.Join(dir, "store.go"), []byte(src), 0644), qt.IsNil)
+}
+
+func signatureOf(t *testing.T, g *Generator, dir, typeName string) string {
+	t.Helper()
+	fset, files := parseSources(t, dir)
+	sig, ok := g.signature(typeName, fset, files)
+	qt.Assert(t, ok, qt.IsTrue)
+	return sig
+}
+
+func parseSources(t *testing.T, dir string) (*token.FileSet, []*ast.File) {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := goparser.ParseFile(fset, filepath.Join(dir, "store.go"), nil, goparser.ParseComments)
+	qt.Assert(t, err, qt.IsNil)
+	return fset, []*ast.File{f}
+}
@@ -0,0 +1,90 @@
+package traceable
+
+import (
+	"go/types"
+	"strconv"
+)
+
+// sizeTag is the length of an argument or result recorded as a tag on the
+// span of a method with RecordSizes.
+type sizeTag struct {
+	key   string
+	value string
+}
+
+// argSizes returns the lengths of the arguments of m to record on its span.
+// Sensitive and deny-listed arguments are skipped, as their lengths can leak
+// their values.
+func (g *Generator) argSizes(m Method) []sizeTag {
+	var sizes []sizeTag
+	for i, t := range m.args {
+		name := m.argNames[i]
+		if !hasLen(t) || m.sensitive[i] || g.deniedName(name) != "" || g.deniedType(t) != "" {
+			continue
+		}
+		if name == "" || name == "_" {
+			name = "arg" + strconv.Itoa(i)
+		}
+		sizes = append(sizes, sizeTag{key: name + ".len", value: "len(a" + strconv.Itoa(i) + ")"})
+	}
+
+	return sizes
+}
+
+// resultSizes returns the lengths of the results of m to record on its span.
+// Deny-listed results are skipped.
+func (g *Generator) resultSizes(m Method) []sizeTag {
+	var sizes []sizeTag
+	for i, t := range m.returns {
+		name := m.returnNames[i]
+		if !hasLen(t) || g.deniedName(name) != "" || g.deniedType(t) != "" {
+			continue
+		}
+		if name == "" || name == "_" {
+			name = "result" + strconv.Itoa(i)
+		}
+		sizes = append(sizes, sizeTag{key: name + ".len", value: "len(" + resultName(i) + ")"})
+	}
+
+	return sizes
+}
+
+// hasLen reports whether the length of values of type t varies, i.e. t is a
+// slice, map, string or channel, whose length is the number of elements
+// buffered in it. The length of an array is part of its type, so arrays
+// are skipped.
+func hasLen(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Slice, *types.Map, *types.Chan:
+		return true
+	case *types.Basic:
+		return u.Info()&types.IsString != 0
+	}
+
+	return false
+}
+
+// printSizes prints the calls recording the lengths of the arguments of m
+// on span, and a deferred call recording the lengths of its results. The
+// deferred call is registered after runtime.FinishSpan, so it runs before
+// the span is finished.
+func (g *Generator) printSizes(m Method) {
+	if !g.RecordSizes {
+		return
+	}
+
+	rt := g.importName(runtimePackagePath)
+	for _, size := range g.argSizes(m) {
+		g.Printf("%s.Tag(span, %q, %s)\n", rt, size.key, size.value)
+	}
+
+	results := g.resultSizes(m)
+	if len(results) == 0 {
+		return
+	}
+	g.Printf("defer func() {\n")
+	for _, size := range results {
+		g.Printf("%s.Tag(span, %q, %s)\n", rt, size.key, size.value)
+	}
+	g.Printf("}()\n")
+}
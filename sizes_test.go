@@ -0,0 +1,58 @@
+package traceable
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestGenerator_sizes(t *testing.T) {
+	pkg := parseSource(t, `package store
+
+import "context"
+
+type Keys []string
+
+type Store interface {
+	//traceable:sensitive pin
+	Put(ctx context.Context, key string, value []byte, pin string, password []byte, _ map[string]int, digest [32]byte, n int) (Keys, error)
+	Watch(ctx context.Context, events chan<- string) (pending <-chan string, err error)
+	Ptr(ctx context.Context, p *[]string) (*string, [4]int)
+}
+`)
+	i, err := pkg.lookupInterface("Store")
+	qt.Assert(t, err, qt.IsNil)
+
+	g := &Generator{}
+	keys := func(sizes []sizeTag) []string {
+		var keys []string
+		for _, s := range sizes {
+			keys = append(keys, s.key+"="+s.value)
+		}
+		return keys
+	}
+
+	methods := make(map[string]Method)
+	for _, m := range i.methods {
+		methods[m.name] = m
+	}
+
+	put := methods["Put"]
+	qt.Check(t, keys(g.argSizes(put)), qt.DeepEquals, []string{"key.len=len(a1)", "value.len=len(a2)", "arg5.len=len(a5)"})
+	qt.Check(t, keys(g.resultSizes(put)), qt.DeepEquals, []string{"result0.len=len(r0)"})
+
+	watch := methods["Watch"]
+	qt.Check(t, keys(g.argSizes(watch)), qt.DeepEquals, []string{"events.len=len(a1)"})
+	qt.Check(t, keys(g.resultSizes(watch)), qt.DeepEquals, []string{"pending.len=len(r0)"})
+
+	ptr := methods["Ptr"]
+	qt.Check(t, g.argSizes(ptr), qt.HasLen, 0)
+	qt.Check(t, g.resultSizes(ptr), qt.HasLen, 0)
+
+	g.Deny = DenyList{Names: []string{"value"}}
+	qt.Check(t, keys(g.argSizes(put)), qt.DeepEquals, []string{"key.len=len(a1)", "arg5.len=len(a5)"})
+
+	g.Deny = DenyList{Types: []string{"map[string]int", "store.Keys"}}
+	qt.Check(t, keys(g.argSizes(put)), qt.DeepEquals, []string{"key.len=len(a1)", "value.len=len(a2)"})
+	qt.Check(t, g.resultSizes(put), qt.HasLen, 0)
+}
@@ -0,0 +1,265 @@
+package traceable
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	extPackagePath = "github.com/opentracing/opentracing-go/ext"
+	extPackageName = "ext"
+)
+
+// tagDirective is the name of the directive listing the arguments of a
+// method that are recorded as tags on its span.
+const tagDirective = "tag"
+
+// spanKinds are the values accepted by the kind directive, which are those
+// of the standard span.kind tag.
+var spanKinds = []string{"client", "server", "producer", "consumer"}
+
+// interfaceTags maps the directives that can annotate an interface to the
+// standard tags they set on the spans of its methods.
+var interfaceTags = map[string]string{
+	"kind":         "span.kind",
+	"component":    "component",
+	"peer.service": "peer.service",
+}
+
+// spanTag is a tag set on every span started by a generated wrapper.
+type spanTag struct {
+	key   string
+	value string
+}
+
+// parseInterfaceTags returns the tags set by the directives annotating the
+// interface name, sorted by key.
+func parseInterfaceTags(name string, d directives) ([]spanTag, error) {
+	var tags []spanTag
+	for directive, key := range interfaceTags {
+		value, ok := d[directive]
+		if !ok {
+			continue
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%s%s on %s must have a value", directivePrefix, directive, name)
+		}
+		if directive == "kind" && !isSpanKind(value) {
+			return nil, fmt.Errorf("%s%s %s on %s is not a span kind; must be one of %s", directivePrefix, directive, value, name, strings.Join(spanKinds, ", "))
+		}
+		tags = append(tags, spanTag{key: key, value: value})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].key < tags[j].key
+	})
+
+	return tags, nil
+}
+
+// hasSpanKind reports whether the spans of i are tagged with their kind,
+// which is the only tag whose value refers to the ext package.
+func (i *Interface) hasSpanKind() bool {
+	for _, tag := range i.tags {
+		if tag.key == "span.kind" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSpanKind(value string) bool {
+	for _, kind := range spanKinds {
+		if value == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// typeSpecAt returns the declaration of the type whose name is at pos in
+// files, or nil if there is none.
+func typeSpecAt(files []*ast.File, pos token.Pos) (*ast.GenDecl, *ast.TypeSpec) {
+	for _, f := range files {
+		if pos < f.Pos() || pos >= f.End() {
+			continue
+		}
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				if ts := spec.(*ast.TypeSpec); ts.Name.Pos() == pos {
+					return gd, ts
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// tagsName returns the name of the variable holding the tags set on the
+// spans started by the wrapper of typeName.
+func tagsName(typeName string) string {
+	return "traced" + getStructName(typeName) + "Tags"
+}
+
+// printTags prints the variable holding the tags of the Interface, if it
+// has any.
+func (g *Generator) printTags(typeName string) {
+	if len(g.Interface.tags) == 0 {
+		return
+	}
+
+	g.Printf("// %s are set on every span started by Traced%s.\n", tagsName(typeName), getStructName(typeName))
+	g.Printf("var %s = %s.Tags{\n", tagsName(typeName), g.importName(openTracingPackagePath))
+	for _, tag := range g.Interface.tags {
+		value := strconv.Quote(tag.value)
+		if tag.key == "span.kind" {
+			value = g.importName(extPackagePath) + ".SpanKindEnum(" + value + ")"
+		}
+		g.Printf("%q: %s,\n", tag.key, value)
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+}
+
+// startSpanOptions returns the arguments following the operation name in
+// the call to runtime.StartSpan of the method m of the wrapper of typeName.
+func (g *Generator) startSpanOptions(typeName string, m Method) string {
+	var opts string
+	if len(g.Interface.tags) > 0 {
+		opts = ", " + tagsName(typeName)
+	}
+
+	return opts + g.extractOption(m)
+}
+
+// argTag is an argument, or a field of one, recorded as a tag on the span of
+// a method, as annotated with //traceable:tag.
+type argTag struct {
+	key string
+	// expr is the value as written in the directive, e.g. req.User.ID.
+	expr string
+	// arg is the index of the parameter the value is read from.
+	arg int
+	// fields are the fields selected from the parameter, in order, including
+	// those of embedded structs the selected field is promoted from.
+	fields []*types.Var
+	// sensitive is the name of the parameter or field in expr annotated as
+	// sensitive, if any.
+	sensitive string
+	// omitEmpty is set if the tag is skipped when the value is empty, as
+	// requested by the omitempty option of a trace struct tag.
+	omitEmpty bool
+}
+
+// parseArgTags parses the value of the directive of m, a comma-separated
+// list of key=expr, or expr to use expr as the key, where expr is the name of
+// a parameter of m optionally followed by a selector of one of its fields,
+// e.g. user.id=req.User.ID. params are the parameters of m and pkg is the
+// package declaring it.
+func (p *parser) parseArgTags(m *Method, pkg *types.Package, params *types.Tuple, directive, value string) ([]argTag, error) {
+	var tags []argTag
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, expr := entry, entry
+		if idx := strings.IndexRune(entry, '='); idx != -1 {
+			key, expr = strings.TrimSpace(entry[:idx]), strings.TrimSpace(entry[idx+1:])
+		}
+		tag := argTag{key: key, expr: expr, arg: -1}
+
+		names := strings.Split(expr, ".")
+		for i := 0; i < params.Len(); i++ {
+			if name := params.At(i).Name(); name == names[0] && name != "_" {
+				tag.arg = i
+			}
+		}
+		if tag.arg == -1 {
+			return nil, fmt.Errorf("%s%s %s on %s: %s is not a parameter of %s", directivePrefix, directive, entry, m.name, names[0], m.name)
+		}
+		if m.sensitive[tag.arg] {
+			tag.sensitive = names[0]
+		}
+
+		t := params.At(tag.arg).Type()
+		for _, name := range names[1:] {
+			obj, index, _ := types.LookupFieldOrMethod(t, true, pkg, name)
+			if _, ok := obj.(*types.Var); !ok {
+				return nil, fmt.Errorf("%s%s %s on %s: %s has no field %s", directivePrefix, directive, entry, m.name, types.TypeString(t, nil), name)
+			}
+			for _, idx := range index {
+				f := derefStruct(t).Field(idx)
+				tag.fields = append(tag.fields, f)
+				if tag.sensitive == "" && p.fieldDirectives[f.Pos()].has(sensitiveDirective) {
+					tag.sensitive = f.Name()
+				}
+				t = f.Type()
+			}
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// derefStruct returns the struct type of t, or the type t points to.
+func derefStruct(t types.Type) *types.Struct {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	return t.Underlying().(*types.Struct)
+}
+
+// printArgTags prints the calls recording the tags of m on span, guarding
+// each with checks that the pointers its value is read through are not nil.
+func (g *Generator) printArgTags(m Method) {
+	rt := g.importName(runtimePackagePath)
+	for _, tag := range m.tags {
+		g.printGuarded(m, tag, func(value string) {
+			g.Printf("%s.Tag(span, %q, %s)\n", rt, tag.key, value)
+		})
+	}
+}
+
+// printGuarded prints the statement printed by print with the expression
+// reading the value of tag, guarded with checks that the pointers the value
+// is read through are not nil and, if tag omits empty values, that the value
+// is not empty.
+func (g *Generator) printGuarded(m Method, tag argTag, print func(value string)) {
+	value := "a" + strconv.Itoa(tag.arg)
+	t := m.args[tag.arg]
+	var guards []string
+	for _, f := range tag.fields {
+		if _, ok := t.Underlying().(*types.Pointer); ok {
+			guards = append(guards, value+" != nil")
+		}
+		value += "." + f.Name()
+		t = f.Type()
+	}
+	if tag.omitEmpty {
+		guards = append(guards, nonEmpty(value, t))
+	}
+
+	if len(guards) > 0 {
+		g.Printf("if %s {\n", strings.Join(guards, " && "))
+	}
+	print(value)
+	if len(guards) > 0 {
+		g.Printf("}\n")
+	}
+}
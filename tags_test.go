@@ -0,0 +1,61 @@
+package traceable
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func Test_parseInterfaceTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		directives directives
+		want       []string
+		wantErr    string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:       "unrelated directives",
+			directives: directives{"skip": ""},
+		},
+		{
+			name: "all",
+			directives: directives{
+				"kind":         "client",
+				"component":    "postgres",
+				"peer.service": "billing-api",
+			},
+			want: []string{"component=postgres", "peer.service=billing-api", "span.kind=client"},
+		},
+		{
+			name:       "unknown kind",
+			directives: directives{"kind": "database"},
+			wantErr:    `//traceable:kind database on Store is not a span kind; must be one of client, server, producer, consumer`,
+		},
+		{
+			name:       "missing value",
+			directives: directives{"component": ""},
+			wantErr:    `//traceable:component on Store must have a value`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseInterfaceTags("Store", tt.directives)
+			if tt.wantErr != "" {
+				qt.Check(t, err, qt.ErrorMatches, tt.wantErr)
+				return
+			}
+			qt.Assert(t, err, qt.IsNil)
+
+			var tags []string
+			for _, tag := range got {
+				tags = append(tags, tag.key+"="+tag.value)
+			}
+			qt.Check(t, tags, qt.DeepEquals, tt.want)
+		})
+	}
+}
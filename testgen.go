@@ -0,0 +1,150 @@
+package traceable
+
+import (
+	"bytes"
+	"go/types"
+	"log"
+	"strings"
+)
+
+// GenerateAllTests generates tests for the wrappers of each of the types.
+func (g *Generator) GenerateAllTests(types []string) error {
+	for _, t := range types {
+		if err := g.GenerateTests(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GenerateTests generates a test for the wrapper of typeName. The test runs
+// every method of the wrapper against a recording fake of the interface and
+// checks that the call is delegated, that a span with the right name is
+// started and that the span is passed on to the wrapped value.
+func (g *Generator) GenerateTests(typeName string) error {
+	log.Printf("generating tests for %s", typeName)
+
+	if err := g.loadInterface(typeName); err != nil {
+		return err
+	}
+	g.sortMethods()
+
+	g.printHeader(typeName, g.fileConstraint(false))
+	g.printImports(contextPackagePath, runtimePackagePath, testingPackagePath, openTracingPackagePath, mockTracerPackagePath)
+	g.printRecorder(typeName)
+	g.printTest(typeName)
+
+	return nil
+}
+
+func (g *Generator) printRecorder(typeName string) {
+	structName := getStructName(typeName)
+	ctx := g.importName(contextPackagePath)
+
+	g.Printf("// recording%s is a %s that records the calls made to it.\n", structName, g.interfaceName(typeName))
+	g.Printf("type recording%s struct {\n", structName)
+	g.Printf("calls []string\n")
+	g.Printf("ctxs []%s.Context\n", ctx)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("var _ %s = (*recording%s)(nil)\n", g.interfaceName(typeName), structName)
+
+	for _, m := range g.Interface.methods {
+		argList, _ := g.params(m)
+		ctxArg := "nil"
+		if m.acceptsContext() {
+			ctxArg = m.contextArg()
+		}
+
+		g.Printf("\n")
+		g.Printf("func (r *recording%s) %s(%s) %s {\n", structName, m.name, strings.Join(argList, ","), g.results(m, true))
+		g.Printf("r.calls = append(r.calls, \"%s\")\n", m.name)
+		g.Printf("r.ctxs = append(r.ctxs, %s)\n", ctxArg)
+		g.Printf("return\n")
+		g.Printf("}\n")
+	}
+}
+
+func (g *Generator) printTest(typeName string) {
+	structName := getStructName(typeName)
+	ctx := g.importName(contextPackagePath)
+	testingPkg := g.importName(testingPackagePath)
+
+	g.Printf("\n")
+	g.Printf("func TestTraced%s(t *%s.T) {\n", structName, testingPkg)
+	g.Printf("tests := []struct {\n")
+	g.Printf("method string\n")
+	g.Printf("traced bool\n")
+	g.Printf("call func(ctx %s.Context, x *Traced%s)\n", ctx, structName)
+	g.Printf("}{\n")
+	for _, m := range g.Interface.methods {
+		g.Printf("{\n")
+		g.Printf("method: \"%s\",\n", m.name)
+		g.Printf("traced: %t,\n", g.traced(structName, m))
+		g.Printf("call: func(ctx %s.Context, x *Traced%s) {\n", ctx, structName)
+		g.Printf("x.%s(%s)\n", m.name, strings.Join(g.testArgs(m), ","))
+		g.Printf("},\n")
+		g.Printf("},\n")
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("for _, tt := range tests {\n")
+	g.Printf("t.Run(tt.method, func(t *%s.T) {\n", testingPkg)
+	g.Printf("tracer := %s.New()\n", g.importName(mockTracerPackagePath))
+	g.Printf("x := &recording%s{}\n", structName)
+	g.Printf("tt.call(%s.Background(), NewTraced%s(x, %s.WithTracer(tracer)))\n", ctx, structName, g.importName(runtimePackagePath))
+	g.Printf("\n")
+	g.Printf("if len(x.calls) != 1 || x.calls[0] != tt.method {\n")
+	g.Printf("t.Fatalf(\"expected a single call to %%s, got %%v\", tt.method, x.calls)\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("spans := tracer.FinishedSpans()\n")
+	g.Printf("if !tt.traced {\n")
+	g.Printf("if len(spans) != 0 {\n")
+	g.Printf("t.Fatalf(\"expected no spans, got %%d\", len(spans))\n")
+	g.Printf("}\n")
+	g.Printf("return\n")
+	g.Printf("}\n")
+	g.Printf("if len(spans) != 1 {\n")
+	g.Printf("t.Fatalf(\"expected a single span, got %%d\", len(spans))\n")
+	g.Printf("}\n")
+	g.Printf("if want := \"%s.\" + tt.method; spans[0].OperationName != want {\n", structName)
+	g.Printf("t.Errorf(\"expected span %%q, got %%q\", want, spans[0].OperationName)\n")
+	g.Printf("}\n")
+	g.Printf("if %s.SpanFromContext(x.ctxs[0]) != spans[0] {\n", g.importName(openTracingPackagePath))
+	g.Printf("t.Errorf(\"expected the span to be passed to %%s\", tt.method)\n")
+	g.Printf("}\n")
+	if len(g.Interface.tags) > 0 {
+		g.Printf("for tag, want := range %s {\n", tagsName(typeName))
+		g.Printf("if got := spans[0].Tag(tag); got != want {\n")
+		g.Printf("t.Errorf(\"expected tag %%s to be %%v, got %%v\", tag, want, got)\n")
+		g.Printf("}\n")
+		g.Printf("}\n")
+	}
+	g.Printf("})\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// testArgs returns the arguments m is called with in generated tests: the
+// test's context for a context argument and the zero value of everything
+// else. Variadic arguments are omitted.
+func (g *Generator) testArgs(m Method) []string {
+	var args []string
+	for i, a := range m.args {
+		if m.isVariadic && i == len(m.args)-1 {
+			break
+		}
+		if isContextType(a) {
+			args = append(args, "ctx")
+			continue
+		}
+
+		var b bytes.Buffer
+		types.WriteType(&b, a, g.packageName)
+		args = append(args, "*new("+b.String()+")")
+	}
+
+	return args
+}
@@ -0,0 +1,87 @@
+package traceable
+
+import (
+	"go/types"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func Test_Generator_testArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		method Method
+		want   []string
+	}{
+		{
+			name:   "no arguments",
+			method: Method{},
+			want:   nil,
+		},
+		{
+			name: "context and values",
+			method: Method{
+				args: []types.Type{
+					newContextType(),
+					types.Typ[types.String],
+					types.NewMap(types.Typ[types.Int], types.Typ[types.String]),
+				},
+			},
+			want: []string{"ctx", "*new(string)", "*new(map[int]string)"},
+		},
+		{
+			name: "variadic argument is omitted",
+			method: Method{
+				args: []types.Type{
+					newContextType(),
+					types.NewSlice(types.Typ[types.String]),
+				},
+				isVariadic: true,
+			},
+			want: []string{"ctx"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			qt.Check(t, g.testArgs(tt.method), qt.DeepEquals, tt.want)
+		})
+	}
+}
+
+func TestGenerator_GenerateTests(t *testing.T) {
+	g := &Generator{
+		packageMap: map[string]string{},
+		pkgs: map[string]*Package{
+			"root/cache": {
+				interfaces: []*Interface{
+					{
+						name: "Cache",
+						methods: []Method{
+							{name: "Set", args: []types.Type{newContextType()}},
+							{name: "Get", args: []types.Type{newContextType()}, skip: true},
+						},
+					},
+				},
+			},
+		},
+		RootPackage:       "root/cache",
+		OutputPackagePath: "root/cache",
+	}
+	qt.Assert(t, g.GenerateTests("Cache"), qt.IsNil)
+
+	out := g.buf.String()
+	qt.Check(t, out, qt.Contains, "func (r *recordingCache) Get(a0 context.Context)")
+	qt.Check(t, out, qt.Contains, "func TestTracedCache(t *testing.T) {")
+	qt.Check(t, out, qt.Contains, "method: \"Get\",\ntraced: false,")
+	qt.Check(t, out, qt.Contains, "method: \"Set\",\ntraced: true,")
+	qt.Check(t, findImports(t, strings.Split(out, "\n")), qt.ContentEquals, []string{
+		"context",
+		"testing",
+		"github.com/ConorNevin/traceable/runtime",
+		"github.com/opentracing/opentracing-go",
+		"github.com/opentracing/opentracing-go/mocktracer",
+	})
+}
@@ -0,0 +1,20 @@
+//go:build !go1.18
+// +build !go1.18
+
+package traceable
+
+import (
+	"go/types"
+)
+
+// isConstraint reports whether ti can only be used as a type constraint,
+// which is never the case before Go 1.18.
+func isConstraint(ti *types.Interface) bool {
+	return false
+}
+
+// hasTypeParams reports whether t is a generic type or function signature,
+// which is never the case before Go 1.18.
+func hasTypeParams(t types.Type) bool {
+	return false
+}
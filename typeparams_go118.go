@@ -0,0 +1,24 @@
+//go:build go1.18
+// +build go1.18
+
+package traceable
+
+import (
+	"go/types"
+)
+
+// isConstraint reports whether ti can only be used as a type constraint.
+func isConstraint(ti *types.Interface) bool {
+	return !ti.IsMethodSet()
+}
+
+// hasTypeParams reports whether t is a generic type or function signature.
+func hasTypeParams(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Named:
+		return t.TypeParams().Len() > 0
+	case *types.Signature:
+		return t.TypeParams().Len() > 0
+	}
+	return false
+}
@@ -0,0 +1,385 @@
+package traceable
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// A Watcher keeps the packages loaded into a Generator in memory so that
+// they can be brought up to date when their source files change. Only the
+// files that changed are parsed again, and the packages are type-checked
+// against the imports found by the initial load rather than loaded from
+// scratch.
+type Watcher struct {
+	g        *Generator
+	patterns []string
+
+	fset    *token.FileSet
+	pkgs    map[string]*watchedPackage
+	imports map[string]*types.Package
+}
+
+type watchedPackage struct {
+	name  string
+	path  string
+	dir   string
+	files map[string]*watchedFile
+	types *types.Package
+
+	// stale is set when the files of the package changed since it was last
+	// type-checked.
+	stale bool
+}
+
+type watchedFile struct {
+	modTime time.Time
+	size    int64
+	syntax  *ast.File
+	// err is the error parsing the latest version of the file, in which case
+	// syntax is that of the last version that could be parsed.
+	err error
+}
+
+// NewWatcher loads the packages matching patterns into g, as ParsePackage
+// does, and watches their source files.
+func NewWatcher(g *Generator, patterns []string) (*Watcher, error) {
+	w := &Watcher{g: g, patterns: patterns}
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// load loads the watched packages from scratch.
+func (w *Watcher) load() error {
+	cfg := w.g.config(loadMode)
+	cfg.Fset = token.NewFileSet()
+	pkgs, err := packages.Load(cfg, w.patterns...)
+	if err != nil {
+		return err
+	}
+	pkgs = withTestVariants(pkgs)
+
+	w.fset = cfg.Fset
+	w.pkgs = make(map[string]*watchedPackage)
+	w.imports = make(map[string]*types.Package)
+	for _, pkg := range pkgs {
+		wp := &watchedPackage{
+			name:  pkg.Name,
+			path:  pkg.PkgPath,
+			files: make(map[string]*watchedFile),
+			types: pkg.Types,
+		}
+		for _, f := range pkg.Syntax {
+			name := w.fset.File(f.Pos()).Name()
+			fi, err := os.Stat(name)
+			if err != nil {
+				return err
+			}
+			wp.dir = filepath.Dir(name)
+			wp.files[name] = &watchedFile{modTime: fi.ModTime(), size: fi.Size(), syntax: f}
+		}
+		for _, i := range pkg.Types.Imports() {
+			w.imports[i.Path()] = i
+		}
+
+		w.pkgs[pkg.PkgPath] = wp
+		if err := w.g.addPackage(pkg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Changed brings the watched packages up to date with their source files,
+// returning the import paths of the packages that were reloaded. A package
+// is reloaded when one of its files is added, removed or modified, or when
+// it imports another watched package that was reloaded. A package with a
+// file that can not be parsed is left as it was until the file is fixed.
+func (w *Watcher) Changed() ([]string, error) {
+	var parseErr error
+	dirty := make(map[string]bool)
+	for _, path := range w.paths() {
+		wp := w.pkgs[path]
+		if err := w.update(wp); err != nil && parseErr == nil {
+			parseErr = err
+		}
+		if wp.stale && !wp.broken() {
+			dirty[wp.path] = true
+		}
+	}
+
+	var reloaded []string
+	for len(dirty) > 0 {
+		wp := w.next(dirty)
+		delete(dirty, wp.path)
+
+		pkg, err := w.check(wp)
+		if _, ok := err.(missingImportError); ok {
+			// The package imports something the initial load did not see,
+			// so start again with a fresh view of the dependencies.
+			if err := w.load(); err != nil {
+				return nil, err
+			}
+			return w.paths(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := w.g.addPackage(pkg); err != nil {
+			return nil, err
+		}
+		reloaded = append(reloaded, wp.path)
+
+		// Packages importing wp are checked again so that they refer to its
+		// new types.
+		for _, other := range w.pkgs {
+			if importsPath(other.types, wp.path) {
+				dirty[other.path] = true
+			}
+		}
+	}
+	sort.Strings(reloaded)
+
+	return reloaded, parseErr
+}
+
+// Wrote tells the Watcher that the caller just wrote the named file, e.g.
+// the generated output, so that the next call to Changed does not reload its
+// package for it. A file that can not be parsed is left for Changed.
+func (w *Watcher) Wrote(name string) error {
+	name, err := filepath.Abs(name)
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	for _, wp := range w.pkgs {
+		if wp.dir != filepath.Dir(name) || !w.g.matchFile(wp.dir, fi) {
+			continue
+		}
+		if f, ok := wp.files[name]; ok && f.modTime.Equal(fi.ModTime()) && f.size == fi.Size() {
+			continue
+		}
+
+		src, err := ioutil.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		syntax, err := parseFile(w.fset, name, src)
+		if err != nil || syntax.Name.Name != wp.name {
+			continue
+		}
+		wp.files[name] = &watchedFile{modTime: fi.ModTime(), size: fi.Size(), syntax: syntax}
+	}
+
+	return nil
+}
+
+// update parses the files of wp that were added or modified since it was
+// last updated and forgets those that were removed, returning the first
+// error parsing them.
+func (w *Watcher) update(wp *watchedPackage) error {
+	infos, err := ioutil.ReadDir(wp.dir)
+	if err != nil {
+		return err
+	}
+
+	var parseErr error
+	seen := make(map[string]bool)
+	for _, fi := range infos {
+		name := filepath.Join(wp.dir, fi.Name())
+		if !w.g.matchFile(wp.dir, fi) {
+			continue
+		}
+		seen[name] = true
+
+		f, ok := wp.files[name]
+		if ok && f.modTime.Equal(fi.ModTime()) && f.size == fi.Size() {
+			continue
+		}
+
+		wp.stale = true
+		src, err := ioutil.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		syntax, err := parseFile(w.fset, name, src)
+		if err == nil && syntax.Name.Name != wp.name {
+			// The file belongs to another package in the same directory,
+			// e.g. an external test package.
+			delete(seen, name)
+			continue
+		}
+		if err != nil {
+			if parseErr == nil {
+				parseErr = err
+			}
+			if ok {
+				syntax = f.syntax
+			}
+		}
+		wp.files[name] = &watchedFile{modTime: fi.ModTime(), size: fi.Size(), syntax: syntax, err: err}
+	}
+	for name := range wp.files {
+		if !seen[name] {
+			wp.stale = true
+			delete(wp.files, name)
+		}
+	}
+
+	return parseErr
+}
+
+// broken reports whether one of the files of wp can not be parsed.
+func (wp *watchedPackage) broken() bool {
+	for _, f := range wp.files {
+		if f.err != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// next returns a package from dirty that does not import any other package
+// in dirty, so that packages are checked after their dependencies.
+func (w *Watcher) next(dirty map[string]bool) *watchedPackage {
+	var paths []string
+	for path := range dirty {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		wp := w.pkgs[path]
+		ready := true
+		for dep := range dirty {
+			if dep != path && importsPath(wp.types, dep) {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			return wp
+		}
+	}
+
+	return w.pkgs[paths[0]]
+}
+
+// check type-checks wp against the imports of the watched packages. Like
+// packages.Load, it tolerates type errors so that a stale generated file in
+// the package does not prevent it from being regenerated.
+func (w *Watcher) check(wp *watchedPackage) (*packages.Package, error) {
+	var names []string
+	for name := range wp.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	syntax := make([]*ast.File, len(names))
+	for i, name := range names {
+		syntax[i] = wp.files[name].syntax
+	}
+
+	for _, f := range syntax {
+		for _, spec := range f.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return nil, err
+			}
+			if w.lookup(path) == nil {
+				return nil, missingImportError(path)
+			}
+		}
+	}
+
+	conf := types.Config{
+		Importer: importerFunc(w.lookupImport),
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(wp.path, w.fset, syntax, nil)
+	wp.types = pkg
+	wp.stale = false
+
+	return &packages.Package{
+		Name:    pkg.Name(),
+		PkgPath: wp.path,
+		Fset:    w.fset,
+		Syntax:  syntax,
+		Types:   pkg,
+	}, nil
+}
+
+func (w *Watcher) lookupImport(path string) (*types.Package, error) {
+	if pkg := w.lookup(path); pkg != nil {
+		return pkg, nil
+	}
+
+	return nil, missingImportError(path)
+}
+
+// lookup returns the package imported as path by the watched packages.
+func (w *Watcher) lookup(path string) *types.Package {
+	if path == "unsafe" {
+		return types.Unsafe
+	}
+	if wp, ok := w.pkgs[path]; ok {
+		return wp.types
+	}
+
+	return w.imports[path]
+}
+
+// paths returns the import paths of the watched packages.
+func (w *Watcher) paths() []string {
+	var paths []string
+	for path := range w.pkgs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+type missingImportError string
+
+func (e missingImportError) Error() string {
+	return fmt.Sprintf("package %s was not loaded", string(e))
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+// importsPath reports whether pkg imports the package at path.
+func importsPath(pkg *types.Package, path string) bool {
+	if pkg == nil {
+		return false
+	}
+	for _, i := range pkg.Imports() {
+		if i.Path() == path {
+			return true
+		}
+	}
+
+	return false
+}
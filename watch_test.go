@@ -0,0 +1,161 @@
+package traceable
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestWatcher_Changed(t *testing.T) {
+	c := qt.New(t)
+
+	dir := c.TempDir()
+	writeFile := func(name, src string) {
+		c.Helper()
+		name = filepath.Join(dir, name)
+		c.Assert(ioutil.WriteFile(name, []byte(src), 0644), qt.IsNil)
+		// Make sure the change is seen even if the file system's timestamps
+		// are coarse.
+		mtime := time.Now().Add(time.Duration(len(src)) * time.Second)
+		c.Assert(os.Chtimes(name, mtime, mtime), qt.IsNil)
+	}
+	writeFile("go.mod", "module example.com/store\n\ngo 1.16\n")
+	writeFile("store.go", `package store
+
+import "context"
+
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+`)
+
+	wd, err := os.Getwd()
+	c.Assert(err, qt.IsNil)
+	c.Assert(os.Chdir(dir), qt.IsNil)
+	c.Cleanup(func() { _ = os.Chdir(wd) })
+
+	g := &Generator{RootPackage: "example.com/store"}
+	w, err := NewWatcher(g, []string{"."})
+	c.Assert(err, qt.IsNil)
+
+	methods := func() []string {
+		i, err := g.pkgs["example.com/store"].lookupInterface("Store")
+		c.Assert(err, qt.IsNil)
+		var names []string
+		for _, m := range i.methods {
+			names = append(names, m.name)
+		}
+		return names
+	}
+	c.Assert(methods(), qt.DeepEquals, []string{"Get"})
+
+	c.Run("unchanged", func(c *qt.C) {
+		reloaded, err := w.Changed()
+		c.Assert(err, qt.IsNil)
+		c.Check(reloaded, qt.HasLen, 0)
+	})
+
+	c.Run("modified", func(c *qt.C) {
+		writeFile("store.go", `package store
+
+import "context"
+
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte) error
+}
+`)
+		reloaded, err := w.Changed()
+		c.Assert(err, qt.IsNil)
+		c.Check(reloaded, qt.DeepEquals, []string{"example.com/store"})
+		c.Check(methods(), qt.DeepEquals, []string{"Get", "Set"})
+	})
+
+	c.Run("parse error", func(c *qt.C) {
+		writeFile("broken.go", "package store\n\nfunc {\n")
+		_, err := w.Changed()
+		c.Check(err, qt.ErrorMatches, `.*broken.go:3:6: expected .*`)
+
+		reloaded, err := w.Changed()
+		c.Assert(err, qt.IsNil)
+		c.Check(reloaded, qt.HasLen, 0)
+
+		writeFile("broken.go", "package store\n\ntype Key string\n")
+		reloaded, err = w.Changed()
+		c.Assert(err, qt.IsNil)
+		c.Check(reloaded, qt.DeepEquals, []string{"example.com/store"})
+	})
+
+	c.Run("new import", func(c *qt.C) {
+		writeFile("store.go", `package store
+
+import (
+	"context"
+	"time"
+)
+
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Expire(ctx context.Context, key string, after time.Duration) error
+}
+`)
+		reloaded, err := w.Changed()
+		c.Assert(err, qt.IsNil)
+		c.Check(reloaded, qt.DeepEquals, []string{"example.com/store"})
+		c.Check(methods(), qt.DeepEquals, []string{"Expire", "Get"})
+	})
+
+	c.Run("invalid interface", func(c *qt.C) {
+		writeFile("store.go", `package store
+
+import "context"
+
+//traceable:kind sideways
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+`)
+		reloaded, err := w.Changed()
+		c.Assert(err, qt.IsNil)
+		c.Check(reloaded, qt.DeepEquals, []string{"example.com/store"})
+		c.Check(g.Generate("Store"), qt.ErrorMatches, `.*sideways.*`)
+
+		writeFile("store.go", `package store
+
+import "context"
+
+//traceable:kind client
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+`)
+		_, err = w.Changed()
+		c.Assert(err, qt.IsNil)
+		g.Reset()
+		c.Check(g.Generate("Store"), qt.IsNil)
+	})
+
+	c.Run("wrote", func(c *qt.C) {
+		writeFile("trace_store.go", "package store\n\ntype TraceStore struct{ Store }\n")
+		c.Assert(w.Wrote(filepath.Join(dir, "trace_store.go")), qt.IsNil)
+		reloaded, err := w.Changed()
+		c.Assert(err, qt.IsNil)
+		c.Check(reloaded, qt.HasLen, 0)
+
+		writeFile("trace_store.go", "package store\n\ntype TraceStore struct{ s Store }\n")
+		reloaded, err = w.Changed()
+		c.Assert(err, qt.IsNil)
+		c.Check(reloaded, qt.DeepEquals, []string{"example.com/store"})
+	})
+
+	c.Run("removed", func(c *qt.C) {
+		c.Assert(os.Remove(filepath.Join(dir, "broken.go")), qt.IsNil)
+		reloaded, err := w.Changed()
+		c.Assert(err, qt.IsNil)
+		c.Check(reloaded, qt.DeepEquals, []string{"example.com/store"})
+	})
+}